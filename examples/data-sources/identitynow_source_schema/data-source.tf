@@ -0,0 +1,26 @@
+data "identitynow_source_schema" "ldap_account" {
+  source_id = identitynow_source.ldap.id
+  name      = "account"
+}
+
+locals {
+  # Only the attributes differing from the schema discovered by the connector
+  ldap_account_overrides = {
+    employeeNumber = { description = "HR employee number" }
+    memberOf       = { is_entitlement = true }
+  }
+}
+
+resource "identitynow_source_schema" "ldap_account" {
+  source_id          = identitynow_source.ldap.id
+  name               = "account"
+  native_object_type = data.identitynow_source_schema.ldap_account.native_object_type
+  identity_attribute = data.identitynow_source_schema.ldap_account.identity_attribute
+  display_attribute  = data.identitynow_source_schema.ldap_account.display_attribute
+  features           = data.identitynow_source_schema.ldap_account.features
+  configuration      = data.identitynow_source_schema.ldap_account.configuration
+  attributes = [
+    for attribute in data.identitynow_source_schema.ldap_account.attributes :
+    merge(attribute, lookup(local.ldap_account_overrides, attribute.name, {}))
+  ]
+}
@@ -0,0 +1,6 @@
+data "identitynow_workflow_actions" "all" {
+}
+
+output "send_email_version" {
+  value = one([for action in data.identitynow_workflow_actions.all.actions : action.version_number if action.id == "sp:send-email"])
+}
@@ -0,0 +1,9 @@
+data "identitynow_workflow_executions" "hr_sync" {
+  workflow_id             = identitynow_workflow.hr_sync.id
+  limit                   = 10
+  include_failure_history = true
+}
+
+output "hr_sync_failed_steps" {
+  value = try([for event in data.identitynow_workflow_executions.hr_sync.latest_failure.history : event.step_name if endswith(event.type, "Failed")], [])
+}
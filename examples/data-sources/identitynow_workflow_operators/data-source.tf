@@ -0,0 +1,6 @@
+data "identitynow_workflow_operators" "all" {
+}
+
+output "operator_ids" {
+  value = data.identitynow_workflow_operators.all.operators[*].id
+}
@@ -0,0 +1,6 @@
+data "identitynow_workflow_triggers" "all" {
+}
+
+output "event_triggers" {
+  value = [for trigger in data.identitynow_workflow_triggers.all.triggers : trigger.id if trigger.type == "EVENT"]
+}
@@ -0,0 +1,10 @@
+ephemeral "identitynow_access_token" "token" {}
+
+# Call an API not covered by the provider, the token is never stored in plan or state
+data "http" "account_activities" {
+  url = "${ephemeral.identitynow_access_token.token.base_url}/v3/account-activities?limit=10"
+  request_headers = {
+    Accept        = "application/json"
+    Authorization = "Bearer ${ephemeral.identitynow_access_token.token.access_token}"
+  }
+}
@@ -0,0 +1,5 @@
+# Connector Rule can be imported by its id
+terraform import identitynow_connector_rule.example 8c190e6787aa4ed9a90bd9d5344523fb
+
+# or by its name
+terraform import identitynow_connector_rule.example "connector_rule:Example Rule"
@@ -0,0 +1,2 @@
+# Identity Attribute is imported by its technical name
+terraform import identitynow_identity_attribute.example costCenter
@@ -0,0 +1,5 @@
+# Identity Profile can be imported by its id
+terraform import identitynow_identity_profile.example 2b838de9db9199d6011db9f5b7da1b4a
+
+# or by its name
+terraform import identitynow_identity_profile.example "identity_profile:Employees"
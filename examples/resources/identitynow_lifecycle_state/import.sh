@@ -0,0 +1,5 @@
+# Lifecycle State is imported by "<identityProfile>/<lifecycleState>", the Identity Profile accepts an id or a name,
+# the Lifecycle State accepts an id or a technical name
+terraform import identitynow_lifecycle_state.example 2b838de9db9199d6011db9f5b7da1b4a/ef38f94347e94562b5bb8424a56397d8
+
+terraform import identitynow_lifecycle_state.example "Employees/active"
@@ -0,0 +1,2 @@
+# Org Config is a singleton, any identifier imports the tenant configuration
+terraform import identitynow_org_config.example org
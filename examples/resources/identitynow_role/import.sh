@@ -0,0 +1,5 @@
+# Role can be imported by its id
+terraform import identitynow_role.example 2c918086749d78830174a1a40e121518
+
+# or by its name
+terraform import identitynow_role.example "role:Role 2567"
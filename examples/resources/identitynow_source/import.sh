@@ -0,0 +1,5 @@
+# Source can be imported by its id
+terraform import identitynow_source.example 2c9180835d191a86015d28455b4a2329
+
+# or by its name
+terraform import identitynow_source.example "source:Active Directory"
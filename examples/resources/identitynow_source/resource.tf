@@ -19,11 +19,14 @@ resource "identitynow_source" "demo_source" {
       seconds = "2"
     }
   })
-  connector_attributes_credentials = jsonencode({
+  # Write-only credentials are not stored in state, increase credentials_version to send changed credentials
+  connector_attributes_credentials_wo = jsonencode({
     username = "test"
     password = "test"
   })
-  type             = data.identitynow_connector.idn_connector.type
-  connector        = data.identitynow_connector.idn_connector.type
-  delete_threshold = 10
+  credentials_version = 1
+  type                = data.identitynow_connector.idn_connector.type
+  connector           = data.identitynow_connector.idn_connector.type
+  delete_threshold    = 10
+  test_connection     = true
 }
@@ -0,0 +1,14 @@
+resource "identitynow_source_aggregation" "hr_accounts" {
+  source_id = identitynow_source.hr.id
+  file      = "${path.module}/hr_accounts.csv"
+
+  triggers = {
+    file_hash = filesha256("${path.module}/hr_accounts.csv")
+  }
+}
+
+resource "identitynow_source_aggregation" "ad_entitlements" {
+  source_id       = identitynow_source.active_directory.id
+  type            = "GROUP_AGGREGATION"
+  timeout_seconds = 3600
+}
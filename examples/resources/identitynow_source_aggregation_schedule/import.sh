@@ -0,0 +1,4 @@
+# Source Aggregation Schedule is imported by "<source>/<type>", the source accepts the Source ID, its name or "source:<name>"
+terraform import identitynow_source_aggregation_schedule.example 2c9180835d2e5168015d32f890ca1581/ACCOUNT_AGGREGATION
+
+terraform import identitynow_source_aggregation_schedule.example "source:Active Directory/GROUP_AGGREGATION"
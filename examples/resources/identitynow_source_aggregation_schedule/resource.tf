@@ -1,5 +1,11 @@
 resource "identitynow_source_aggregation_schedule" "test_account_schedule" {
-  source_cloud_id  = identitynow_source.demo_source.cloud_external_id
-  cron_expression  = "0 5 0 * * ?"
-  aggregation_type = "account"
+  source_id       = identitynow_source.demo_source.id
+  cron_expression = "0 5 0 * * ?"
+  type            = "ACCOUNT_AGGREGATION"
+}
+
+resource "identitynow_source_aggregation_schedule" "test_group_schedule" {
+  source_id       = identitynow_source.demo_source.id
+  cron_expression = "0 0 6 ? * MON-FRI"
+  type            = "GROUP_AGGREGATION"
 }
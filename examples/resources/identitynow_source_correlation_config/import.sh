@@ -0,0 +1,4 @@
+# Source Correlation Config is imported by the source, which accepts an id or a name
+terraform import identitynow_source_correlation_config.example 2c9180835d191a86015d28455b4a2329
+
+terraform import identitynow_source_correlation_config.example "source:Active Directory"
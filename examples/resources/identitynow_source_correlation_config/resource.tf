@@ -0,0 +1,16 @@
+resource "identitynow_source_correlation_config" "demo_source" {
+  source_id = identitynow_source.demo_source.id
+  attribute_assignments = [
+    {
+      identity_attribute = "email"
+      account_attribute  = "mail"
+      ignore_case        = true
+    },
+    {
+      identity_attribute = "uid"
+      account_attribute  = "sAMAccountName"
+      complex            = true
+      match_mode         = "START"
+    }
+  ]
+}
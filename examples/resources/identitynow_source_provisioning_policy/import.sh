@@ -0,0 +1,4 @@
+# Source Provisioning Policy is imported by "<source>/<usageType>", the source accepts an id or a name
+terraform import identitynow_source_provisioning_policy.example 2c9180835d191a86015d28455b4a2329/CREATE
+
+terraform import identitynow_source_provisioning_policy.example "source:Active Directory/UPDATE"
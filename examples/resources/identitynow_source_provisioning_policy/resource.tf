@@ -0,0 +1,32 @@
+resource "identitynow_source_provisioning_policy" "account_create" {
+  source_id   = identitynow_source.demo_source.id
+  usage_type  = "CREATE"
+  name        = "Account"
+  description = "Attributes of new accounts"
+  fields = [
+    {
+      name        = "uid"
+      is_required = true
+      transform = jsonencode({
+        type = "identityAttribute"
+        attributes = {
+          name = "uid"
+        }
+      })
+    },
+    {
+      name = "password"
+      type = "secret"
+      transform = jsonencode({
+        type = "rule"
+        attributes = {
+          name = "Create Password"
+        }
+      })
+    },
+    {
+      name            = "groups"
+      is_multi_valued = true
+    }
+  ]
+}
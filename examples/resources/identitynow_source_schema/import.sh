@@ -0,0 +1,4 @@
+# Source Schema is imported by "<source>/<schema>", both parts accept an id or a name
+terraform import identitynow_source_schema.example 2c9180835d191a86015d28455b4a2329/2c9180835d191a86015d28455b4a2330
+
+terraform import identitynow_source_schema.example "source:Active Directory/account"
@@ -0,0 +1,5 @@
+# Transform can be imported by its id
+terraform import identitynow_transform.example 2cd78adghjkja34jh2b1hkjhasuecd
+
+# or by its name
+terraform import identitynow_transform.example "transform:Timestamp To Date"
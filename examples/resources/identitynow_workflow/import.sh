@@ -0,0 +1,5 @@
+# Workflow can be imported by its id
+terraform import identitynow_workflow.example d201c5e9-d37b-4aff-af14-66414f39d569
+
+# or by its name
+terraform import identitynow_workflow.example "workflow:Send Email"
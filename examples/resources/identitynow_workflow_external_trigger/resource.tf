@@ -0,0 +1,12 @@
+resource "identitynow_workflow_external_trigger" "hr_sync" {
+  workflow_id = identitynow_workflow.hr_sync.id
+
+  # Change the value to rotate the client id and secret
+  rotation_triggers = {
+    rotated_at = "2026-10-01"
+  }
+}
+
+output "hr_sync_url" {
+  value = identitynow_workflow_external_trigger.hr_sync.url
+}
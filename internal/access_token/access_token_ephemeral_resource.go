@@ -0,0 +1,96 @@
+package access_token
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &accessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &accessTokenEphemeralResource{}
+)
+
+// The shortest lifetime left of the returned token, so other providers do not receive a token expiring during the apply.
+const minTokenLifetime = 10 * time.Minute
+
+func NewAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &accessTokenEphemeralResource{}
+}
+
+type accessTokenEphemeralResource struct {
+	apiClient *custom.APIClient
+}
+
+func (r *accessTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*custom.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *sailpoint.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.apiClient = client
+}
+
+func (r *accessTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (r *accessTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Access token of the provider credentials for calling IdentityNow APIs with other providers, it is never stored in plan or state. " +
+			"A new token is requested when the token of the provider expires within 10 minutes",
+		Attributes: map[string]schema.Attribute{
+			"access_token": schema.StringAttribute{
+				Description: "The bearer token",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"token_type": schema.StringAttribute{
+				Description: "The type of the token, usually 'bearer'",
+				Computed:    true,
+			},
+			"base_url": schema.StringAttribute{
+				Description: "The URL of the tenant API the token is valid for, e.g. 'https://tenant.api.identitynow.com'",
+				Computed:    true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "Expiry of the token in RFC 3339 format",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *accessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	token, err := r.apiClient.AccessToken(ctx, minTokenLifetime)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Requesting Access Token",
+			"Could not request Access Token: "+err.Error(),
+		)
+		return
+	}
+
+	result := accessTokenModel{
+		AccessToken: types.StringValue(token.AccessToken),
+		TokenType:   types.StringValue(token.Type()),
+		BaseUrl:     types.StringValue(r.apiClient.BaseURL()),
+		ExpiresAt:   types.StringNull(),
+	}
+	if !token.Expiry.IsZero() {
+		result.ExpiresAt = types.StringValue(token.Expiry.UTC().Format(time.RFC3339))
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &result)...)
+}
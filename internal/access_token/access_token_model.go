@@ -0,0 +1,12 @@
+package access_token
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type accessTokenModel struct {
+	AccessToken types.String `tfsdk:"access_token"`
+	TokenType   types.String `tfsdk:"token_type"`
+	BaseUrl     types.String `tfsdk:"base_url"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+}
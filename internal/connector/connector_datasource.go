@@ -21,7 +21,8 @@ func NewConnectorDataSource() datasource.DataSource {
 }
 
 type connectorDataSource struct {
-	apiClient *sailpoint.APIClient
+	apiClient    *sailpoint.APIClient
+	customClient *custom.APIClient
 }
 
 func (d *connectorDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -38,6 +39,7 @@ func (d *connectorDataSource) Configure(ctx context.Context, req datasource.Conf
 	}
 
 	d.apiClient = client.ApiClient
+	d.customClient = client
 }
 
 func (d *connectorDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -59,6 +61,30 @@ func (d *connectorDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				Description: "The connector script name",
 				Computed:    true,
 			},
+			"attributes": schema.ListNestedAttribute{
+				Description: "The connector attributes of sources, as defined by the source configuration form of the connector, null when the form can not be read",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Description: "The key of the attribute in `connector_attributes`",
+							Computed:    true,
+						},
+						"label": schema.StringAttribute{
+							Description: "The label of the attribute",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The form field type of the attribute, e.g. 'text', 'secret', 'number', 'checkbox' or 'list'",
+							Computed:    true,
+						},
+						"required": schema.BoolAttribute{
+							Description: "Flag indicating whether or not the attribute is required",
+							Computed:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -90,5 +116,24 @@ func (d *connectorDataSource) Read(ctx context.Context, req datasource.ReadReque
 		Type:       types.StringPointerValue(connectors[0].Type),
 		ScriptName: types.StringPointerValue(connectors[0].ScriptName),
 	}
+	attributes, spResp, err := d.customClient.ConnectorAttributes(ctx, model.ScriptName.ValueString())
+	if err != nil {
+		// The connector is still usable, e.g. for its script name, without the attribute specification
+		resp.Diagnostics.AddWarning(
+			"Unable to Read Connector Attributes",
+			"Could not read attributes of Connector '"+name+"', `attributes` is null: "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		return
+	}
+	model.Attributes = make([]connectorAttributeModel, len(attributes))
+	for i, attribute := range attributes {
+		model.Attributes[i] = connectorAttributeModel{
+			Key:      types.StringValue(attribute.Key),
+			Label:    types.StringValue(attribute.Label),
+			Type:     types.StringValue(attribute.Type),
+			Required: types.BoolValue(attribute.Required),
+		}
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
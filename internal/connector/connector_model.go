@@ -3,7 +3,15 @@ package connector
 import "github.com/hashicorp/terraform-plugin-framework/types"
 
 type connectorModel struct {
-	Name       types.String `tfsdk:"name"`
-	Type       types.String `tfsdk:"type"`
-	ScriptName types.String `tfsdk:"script_name"`
+	Name       types.String              `tfsdk:"name"`
+	Type       types.String              `tfsdk:"type"`
+	ScriptName types.String              `tfsdk:"script_name"`
+	Attributes []connectorAttributeModel `tfsdk:"attributes"`
+}
+
+type connectorAttributeModel struct {
+	Key      types.String `tfsdk:"key"`
+	Label    types.String `tfsdk:"label"`
+	Type     types.String `tfsdk:"type"`
+	Required types.Bool   `tfsdk:"required"`
 }
@@ -0,0 +1,61 @@
+package connector_rule
+
+import (
+	"context"
+	"terraform-provider-identitynow/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sailpointBeta "github.com/sailpoint-oss/golang-sdk/v2/api_beta"
+)
+
+// Implementation of IdentityNow Connector Rule listing for `terraform query` - https://developer.sailpoint.com/idn/api/beta/get-connector-rule-list
+var (
+	_ list.ListResource              = &connectorRuleListResource{}
+	_ list.ListResourceWithConfigure = &connectorRuleListResource{}
+)
+
+func NewConnectorRuleListResource() list.ListResource {
+	return &connectorRuleListResource{}
+}
+
+// connectorRuleListResource shares Configure, Metadata and the state mapping with the managed resource.
+type connectorRuleListResource struct {
+	connectorRuleResource
+}
+
+func (r *connectorRuleListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{}
+}
+
+func (r *connectorRuleListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	rules, spResp, err := r.apiClient.Beta.ConnectorRuleManagementAPI.GetConnectorRuleList(ctx).Execute()
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Error Listing Connector Rules",
+			"Could not list Connector Rules: "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = util.StreamListResults(ctx, req, rules, func(rule *sailpointBeta.ConnectorRuleResponse, result *list.ListResult) {
+		result.DisplayName = rule.Name
+		util.SetIdResourceIdentity(ctx, result.Identity, types.StringValue(rule.Id), &result.Diagnostics)
+		if !req.IncludeResource {
+			return
+		}
+		// All attributes of the remote rule are listed
+		model := connectorRuleModel{
+			Attributes: util.MarshalToJsonType(rule.Attributes, &result.Diagnostics),
+		}
+		r.mapToTerraformModel(&model, rule, &result.Diagnostics)
+		if result.Diagnostics.HasError() {
+			return
+		}
+		result.Diagnostics.Append(result.Resource.Set(ctx, &model)...)
+	})
+}
@@ -12,13 +12,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	sailpoint "github.com/sailpoint-oss/golang-sdk/v2"
 	sailpointBeta "github.com/sailpoint-oss/golang-sdk/v2/api_beta"
+	"net/http"
 	"terraform-provider-identitynow/internal/sailpoint/custom"
 	"terraform-provider-identitynow/internal/util"
 )
 
 var (
-	_ resource.Resource              = &connectorRuleResource{}
-	_ resource.ResourceWithConfigure = &connectorRuleResource{}
+	_ resource.Resource                = &connectorRuleResource{}
+	_ resource.ResourceWithConfigure   = &connectorRuleResource{}
+	_ resource.ResourceWithImportState = &connectorRuleResource{}
+	_ resource.ResourceWithIdentity    = &connectorRuleResource{}
 )
 
 func NewConnectorRuleResource() resource.Resource {
@@ -49,6 +52,10 @@ func (r *connectorRuleResource) Metadata(_ context.Context, req resource.Metadat
 	resp.TypeName = req.ProviderTypeName + "_connector_rule"
 }
 
+func (r *connectorRuleResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = util.IdResourceIdentitySchema()
+}
+
 func (r *connectorRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
@@ -165,6 +172,10 @@ func (r *connectorRuleResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	util.SetIdResourceIdentity(ctx, resp.Identity, plan.Id, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(util.MarkCreated(ctx, resp.Private)...)
+
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
@@ -175,9 +186,12 @@ func (r *connectorRuleResource) Read(ctx context.Context, req resource.ReadReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	util.SetIdResourceIdentity(ctx, resp.Identity, state.Id, &resp.Diagnostics)
 
-	ruleResp, spResp, err := r.apiClient.Beta.ConnectorRuleManagementAPI.GetConnectorRule(ctx, state.Id.ValueString()).Execute()
-	if spResp.StatusCode == 404 {
+	ruleResp, spResp, err := util.ReadAfterWrite(ctx, req.Private, func() (*sailpointBeta.ConnectorRuleResponse, *http.Response, error) {
+		return r.apiClient.Beta.ConnectorRuleManagementAPI.GetConnectorRule(ctx, state.Id.ValueString()).Execute()
+	})
+	if util.IsNotFound(spResp) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -225,6 +239,7 @@ func (r *connectorRuleResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	util.SetIdResourceIdentity(ctx, resp.Identity, plan.Id, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
@@ -355,3 +370,52 @@ func (r *connectorRuleResource) mapToTerraformModel(model *connectorRuleModel, r
 	}
 	model.Attributes = util.MarshalToJsonTypeWithDefinedSchema(resp.Attributes, model.Attributes, diagnostics)
 }
+
+func (r *connectorRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	key := util.ParseImportKey(util.GetImportId(ctx, req, &resp.Diagnostics), "connector_rule")
+	var rule *sailpointBeta.ConnectorRuleResponse
+	if key.IsName() {
+		rules, spResp, err := r.apiClient.Beta.ConnectorRuleManagementAPI.GetConnectorRuleList(ctx).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Connector Rule",
+				"Could not list Connector Rules: "+err.Error()+"\n"+util.GetBody(spResp),
+			)
+			return
+		}
+		for i := range rules {
+			if rules[i].Name == key.Name {
+				rule = &rules[i]
+				break
+			}
+		}
+		if rule == nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Connector Rule",
+				"Could not find Connector Rule '"+key.Name+"'",
+			)
+			return
+		}
+	} else {
+		ruleResp, spResp, err := r.apiClient.Beta.ConnectorRuleManagementAPI.GetConnectorRule(ctx, key.Id).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Connector Rule",
+				"Could not find Connector Rule '"+key.Id+"': "+err.Error()+"\n"+util.GetBody(spResp),
+			)
+			return
+		}
+		rule = ruleResp
+	}
+
+	// All attributes of the remote rule are imported
+	state := connectorRuleModel{
+		Attributes: util.MarshalToJsonType(rule.Attributes, &resp.Diagnostics),
+	}
+	r.mapToTerraformModel(&state, rule, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	util.SetIdResourceIdentity(ctx, resp.Identity, state.Id, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,68 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+)
+
+// Run implements the `export` subcommand of the provider binary. It walks the tenant and writes Terraform
+// configuration together with matching `import {}` blocks into the output directory.
+//
+// Tenant credentials are taken from the same environment variables the provider uses:
+// IDN_HOST, IDN_CLIENT_ID and IDN_CLIENT_SECRET.
+func Run(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	outDir := flags.String("out", ".", "directory the generated .tf files are written to")
+	types := flags.String("types", strings.Join(AllTypes, ","), "comma separated list of resource types to export")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	selectedTypes, err := parseTypes(*types)
+	if err != nil {
+		return err
+	}
+	client, err := newAPIClientFromEnv()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		return err
+	}
+
+	exporter := newExporter(client)
+	if err := exporter.collect(ctx, selectedTypes); err != nil {
+		return err
+	}
+	return exporter.write(*outDir)
+}
+
+func parseTypes(value string) ([]string, error) {
+	var selected []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimPrefix(strings.TrimSpace(item), "identitynow_")
+		if item == "" {
+			continue
+		}
+		if !isKnownType(item) {
+			return nil, fmt.Errorf("unsupported resource type '%s', supported types are: %s", item, strings.Join(AllTypes, ", "))
+		}
+		selected = append(selected, item)
+	}
+	return selected, nil
+}
+
+func newAPIClientFromEnv() (*custom.APIClient, error) {
+	host := os.Getenv("IDN_HOST")
+	clientId := os.Getenv("IDN_CLIENT_ID")
+	clientSecret := os.Getenv("IDN_CLIENT_SECRET")
+	if host == "" || clientId == "" || clientSecret == "" {
+		return nil, errors.New("IDN_HOST, IDN_CLIENT_ID and IDN_CLIENT_SECRET environment variables must be set")
+	}
+	return custom.NewAPIClientForTenant(host, clientId, clientSecret), nil
+}
@@ -0,0 +1,465 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"terraform-provider-identitynow/internal/connector_rule"
+	"terraform-provider-identitynow/internal/identity_attribute"
+	"terraform-provider-identitynow/internal/identity_profile"
+	"terraform-provider-identitynow/internal/lifecycle_state"
+	"terraform-provider-identitynow/internal/role"
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"terraform-provider-identitynow/internal/source"
+	"terraform-provider-identitynow/internal/source_schema"
+	"terraform-provider-identitynow/internal/transform"
+	"terraform-provider-identitynow/internal/util"
+	"terraform-provider-identitynow/internal/workflow"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v2"
+	sailpointBeta "github.com/sailpoint-oss/golang-sdk/v2/api_beta"
+	sailpointV3 "github.com/sailpoint-oss/golang-sdk/v2/api_v3"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	typeSource            = "source"
+	typeSourceSchema      = "source_schema"
+	typeTransform         = "transform"
+	typeConnectorRule     = "connector_rule"
+	typeIdentityProfile   = "identity_profile"
+	typeLifecycleState    = "lifecycle_state"
+	typeIdentityAttribute = "identity_attribute"
+	typeWorkflow          = "workflow"
+	typeRole              = "role"
+)
+
+// AllTypes lists exportable resource types (without the provider prefix) in the order they are exported.
+var AllTypes = []string{
+	typeSource,
+	typeSourceSchema,
+	typeTransform,
+	typeConnectorRule,
+	typeIdentityProfile,
+	typeLifecycleState,
+	typeIdentityAttribute,
+	typeWorkflow,
+	typeRole,
+}
+
+var resourceFactories = map[string]func() resource.Resource{
+	typeSource:            source.NewSourceResource,
+	typeSourceSchema:      source_schema.NewSourceSchemaResource,
+	typeTransform:         transform.NewTransformResource,
+	typeConnectorRule:     connector_rule.NewConnectorRuleResource,
+	typeIdentityProfile:   identity_profile.NewIdentityProfileResource,
+	typeLifecycleState:    lifecycle_state.NewLifecycleStateResource,
+	typeIdentityAttribute: identity_attribute.NewIdentityAttributeResource,
+	typeWorkflow:          workflow.NewWorkflowResource,
+	typeRole:              role.NewRoleResource,
+}
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9_]+`)
+
+func isKnownType(typeName string) bool {
+	return slices.Contains(AllTypes, typeName)
+}
+
+// candidate is a tenant object found during discovery, identified by the import identifier of its resource.
+type candidate struct {
+	importId string
+	label    string
+}
+
+// exportedResource is a tenant object with its full Terraform state, as produced by ImportState and Read.
+type exportedResource struct {
+	typeName string
+	name     string
+	importId string
+	id       string
+	schema   schema.Schema
+	state    tftypes.Value
+}
+
+func (r *exportedResource) address() string {
+	return r.typeName + "." + r.name
+}
+
+type exporter struct {
+	client    *custom.APIClient
+	resources []*exportedResource
+	names     map[string]map[string]bool
+
+	sources          []sailpointV3.Source
+	identityProfiles []sailpointBeta.IdentityProfile
+}
+
+func newExporter(client *custom.APIClient) *exporter {
+	return &exporter{
+		client: client,
+		names:  make(map[string]map[string]bool),
+	}
+}
+
+func (e *exporter) collect(ctx context.Context, selectedTypes []string) error {
+	for _, typeName := range AllTypes {
+		if !slices.Contains(selectedTypes, typeName) {
+			continue
+		}
+		candidates, err := e.discover(ctx, typeName)
+		if err != nil {
+			return err
+		}
+		log.Printf("Exporting %d %s objects", len(candidates), typeName)
+		for _, item := range candidates {
+			exported, err := e.read(ctx, typeName, item)
+			if err != nil {
+				log.Printf("Skipping %s '%s': %s", typeName, item.label, err.Error())
+				continue
+			}
+			if exported != nil {
+				e.resources = append(e.resources, exported)
+			}
+		}
+	}
+	return nil
+}
+
+func (e *exporter) discover(ctx context.Context, typeName string) ([]candidate, error) {
+	switch typeName {
+	case typeSource:
+		return e.discoverSources(ctx)
+	case typeSourceSchema:
+		return e.discoverSourceSchemas(ctx)
+	case typeTransform:
+		return e.discoverTransforms(ctx)
+	case typeConnectorRule:
+		return e.discoverConnectorRules(ctx)
+	case typeIdentityProfile:
+		return e.discoverIdentityProfiles(ctx)
+	case typeLifecycleState:
+		return e.discoverLifecycleStates(ctx)
+	case typeIdentityAttribute:
+		return e.discoverIdentityAttributes(ctx)
+	case typeWorkflow:
+		return e.discoverWorkflows(ctx)
+	case typeRole:
+		return e.discoverRoles(ctx)
+	}
+	return nil, fmt.Errorf("unsupported resource type '%s'", typeName)
+}
+
+func (e *exporter) listSources(ctx context.Context) ([]sailpointV3.Source, error) {
+	if e.sources == nil {
+		sources, spResp, err := sailpoint.PaginateWithDefaults[sailpointV3.Source](e.client.ApiClient.V3.SourcesAPI.ListSources(ctx))
+		if err != nil {
+			return nil, apiError("list Sources", err, spResp)
+		}
+		e.sources = sources
+	}
+	return e.sources, nil
+}
+
+func (e *exporter) listIdentityProfiles(ctx context.Context) ([]sailpointBeta.IdentityProfile, error) {
+	if e.identityProfiles == nil {
+		identityProfiles, spResp, err := sailpoint.PaginateWithDefaults[sailpointBeta.IdentityProfile](e.client.ApiClient.Beta.IdentityProfilesAPI.ListIdentityProfiles(ctx))
+		if err != nil {
+			return nil, apiError("list Identity Profiles", err, spResp)
+		}
+		e.identityProfiles = identityProfiles
+	}
+	return e.identityProfiles, nil
+}
+
+func (e *exporter) discoverSources(ctx context.Context) ([]candidate, error) {
+	sources, err := e.listSources(ctx)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]candidate, 0, len(sources))
+	for _, item := range sources {
+		candidates = append(candidates, candidate{importId: *item.Id, label: item.Name})
+	}
+	return candidates, nil
+}
+
+func (e *exporter) discoverSourceSchemas(ctx context.Context) ([]candidate, error) {
+	sources, err := e.listSources(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []candidate
+	for _, item := range sources {
+		schemas, spResp, err := e.client.ApiClient.V3.SourcesAPI.GetSourceSchemas(ctx, *item.Id).Execute()
+		if err != nil {
+			return nil, apiError("list Schemas of Source '"+item.Name+"'", err, spResp)
+		}
+		for _, sourceSchema := range schemas {
+			if sourceSchema.Id == nil || sourceSchema.Name == nil {
+				continue
+			}
+			candidates = append(candidates, candidate{
+				importId: *item.Id + "/" + *sourceSchema.Id,
+				label:    item.Name + "_" + *sourceSchema.Name,
+			})
+		}
+	}
+	return candidates, nil
+}
+
+func (e *exporter) discoverTransforms(ctx context.Context) ([]candidate, error) {
+	transforms, spResp, err := sailpoint.PaginateWithDefaults[sailpointV3.TransformRead](e.client.ApiClient.V3.TransformsAPI.ListTransforms(ctx))
+	if err != nil {
+		return nil, apiError("list Transforms", err, spResp)
+	}
+	var candidates []candidate
+	for _, item := range transforms {
+		// Internal transforms are provided by IdentityNow and cannot be managed
+		if item.Internal {
+			continue
+		}
+		candidates = append(candidates, candidate{importId: item.Id, label: item.Name})
+	}
+	return candidates, nil
+}
+
+func (e *exporter) discoverConnectorRules(ctx context.Context) ([]candidate, error) {
+	rules, spResp, err := e.client.ApiClient.Beta.ConnectorRuleManagementAPI.GetConnectorRuleList(ctx).Execute()
+	if err != nil {
+		return nil, apiError("list Connector Rules", err, spResp)
+	}
+	candidates := make([]candidate, 0, len(rules))
+	for _, item := range rules {
+		candidates = append(candidates, candidate{importId: item.Id, label: item.Name})
+	}
+	return candidates, nil
+}
+
+func (e *exporter) discoverIdentityProfiles(ctx context.Context) ([]candidate, error) {
+	identityProfiles, err := e.listIdentityProfiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]candidate, 0, len(identityProfiles))
+	for _, item := range identityProfiles {
+		candidates = append(candidates, candidate{importId: *item.Id, label: item.Name})
+	}
+	return candidates, nil
+}
+
+func (e *exporter) discoverLifecycleStates(ctx context.Context) ([]candidate, error) {
+	identityProfiles, err := e.listIdentityProfiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []candidate
+	for _, item := range identityProfiles {
+		lifecycleStates, spResp, err := e.client.ApiClient.V3.LifecycleStatesAPI.GetLifecycleStates(ctx, *item.Id).Execute()
+		if err != nil {
+			return nil, apiError("list Lifecycle States of Identity Profile '"+item.Name+"'", err, spResp)
+		}
+		for _, lifecycleState := range lifecycleStates {
+			candidates = append(candidates, candidate{
+				importId: *item.Id + "/" + *lifecycleState.Id,
+				label:    item.Name + "_" + lifecycleState.TechnicalName,
+			})
+		}
+	}
+	return candidates, nil
+}
+
+func (e *exporter) discoverIdentityAttributes(ctx context.Context) ([]candidate, error) {
+	identityAttributes, spResp, err := e.client.ApiClient.Beta.IdentityAttributesAPI.ListIdentityAttributes(ctx).Execute()
+	if err != nil {
+		return nil, apiError("list Identity Attributes", err, spResp)
+	}
+	var candidates []candidate
+	for _, item := range identityAttributes {
+		// System attributes are not configurable
+		if item.System != nil && *item.System {
+			continue
+		}
+		candidates = append(candidates, candidate{importId: item.Name, label: item.Name})
+	}
+	return candidates, nil
+}
+
+func (e *exporter) discoverWorkflows(ctx context.Context) ([]candidate, error) {
+	workflows, spResp, err := e.client.ApiClient.Beta.WorkflowsAPI.ListWorkflows(ctx).Execute()
+	if err != nil {
+		return nil, apiError("list Workflows", err, spResp)
+	}
+	var candidates []candidate
+	for _, item := range workflows {
+		if item.Id == nil || item.Name == nil {
+			continue
+		}
+		candidates = append(candidates, candidate{importId: *item.Id, label: *item.Name})
+	}
+	return candidates, nil
+}
+
+func (e *exporter) discoverRoles(ctx context.Context) ([]candidate, error) {
+	// Roles API allows at most 50 items per page
+	roles, spResp, err := sailpoint.Paginate[sailpointV3.Role](e.client.ApiClient.V3.RolesAPI.ListRoles(ctx), 0, 50, 10000)
+	if err != nil {
+		return nil, apiError("list Roles", err, spResp)
+	}
+	var candidates []candidate
+	for _, item := range roles {
+		if item.Id == nil {
+			continue
+		}
+		candidates = append(candidates, candidate{importId: *item.Id, label: item.Name})
+	}
+	return candidates, nil
+}
+
+// read produces the full state of an object the same way `terraform import` does: ImportState followed by Read.
+func (e *exporter) read(ctx context.Context, typeName string, item candidate) (*exportedResource, error) {
+	res := resourceFactories[typeName]()
+	if configurable, ok := res.(resource.ResourceWithConfigure); ok {
+		configureResp := &resource.ConfigureResponse{}
+		configurable.Configure(ctx, resource.ConfigureRequest{ProviderData: e.client}, configureResp)
+		if configureResp.Diagnostics.HasError() {
+			return nil, diagnosticsError(configureResp.Diagnostics)
+		}
+	}
+	importer, ok := res.(resource.ResourceWithImportState)
+	if !ok {
+		return nil, fmt.Errorf("resource type '%s' does not support import", typeName)
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	res.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		return nil, diagnosticsError(schemaResp.Diagnostics)
+	}
+	emptyState := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	importResp := &resource.ImportStateResponse{State: emptyState}
+	importer.ImportState(ctx, resource.ImportStateRequest{ID: item.importId}, importResp)
+	if importResp.Diagnostics.HasError() {
+		return nil, diagnosticsError(importResp.Diagnostics)
+	}
+
+	readResp := &resource.ReadResponse{State: importResp.State}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		return nil, diagnosticsError(readResp.Diagnostics)
+	}
+	if readResp.State.Raw.IsNull() {
+		// Object was removed in the meantime
+		return nil, nil
+	}
+
+	exported := &exportedResource{
+		typeName: "identitynow_" + typeName,
+		name:     e.uniqueName(typeName, item.label),
+		importId: item.importId,
+		schema:   schemaResp.Schema,
+		state:    readResp.State.Raw,
+	}
+	if _, ok := schemaResp.Schema.Attributes["id"]; ok {
+		var attributes map[string]tftypes.Value
+		if err := readResp.State.Raw.As(&attributes); err != nil {
+			return nil, err
+		}
+		if err := attributes["id"].As(&exported.id); err != nil {
+			return nil, err
+		}
+	}
+	return exported, nil
+}
+
+// uniqueName converts a display name of an object into a Terraform resource name, unique within its type.
+func (e *exporter) uniqueName(typeName, label string) string {
+	name := strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ToLower(label), "_"), "_")
+	if name == "" {
+		name = typeName
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	if e.names[typeName] == nil {
+		e.names[typeName] = make(map[string]bool)
+	}
+	unique := name
+	for i := 2; e.names[typeName][unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	e.names[typeName][unique] = true
+	return unique
+}
+
+func (e *exporter) write(outDir string) error {
+	refs := make(references)
+	for _, exported := range e.resources {
+		if exported.id != "" {
+			refs[exported.id] = resourceAttributeTraversal(exported.typeName, exported.name, "id")
+		}
+	}
+
+	files := make(map[string]*hclwrite.File)
+	imports := hclwrite.NewEmptyFile()
+	for _, exported := range e.resources {
+		file, ok := files[exported.typeName]
+		if !ok {
+			file = hclwrite.NewEmptyFile()
+			files[exported.typeName] = file
+		} else {
+			file.Body().AppendNewline()
+		}
+		block := file.Body().AppendNewBlock("resource", []string{exported.typeName, exported.name})
+		if err := writeAttributes(block.Body(), exported.schema.Attributes, exported.state, refs.without(exported.id)); err != nil {
+			return fmt.Errorf("could not generate configuration of %s: %w", exported.address(), err)
+		}
+
+		if len(imports.Body().Blocks()) > 0 {
+			imports.Body().AppendNewline()
+		}
+		importBody := imports.Body().AppendNewBlock("import", nil).Body()
+		importBody.SetAttributeTraversal("to", resourceTraversal(exported.typeName, exported.name))
+		importBody.SetAttributeValue("id", cty.StringVal(exported.importId))
+	}
+
+	for typeName, file := range files {
+		fileName := strings.TrimPrefix(typeName, "identitynow_") + ".tf"
+		if err := os.WriteFile(filepath.Join(outDir, fileName), hclwrite.Format(file.Bytes()), 0644); err != nil {
+			return err
+		}
+	}
+	if err := os.WriteFile(filepath.Join(outDir, "imports.tf"), hclwrite.Format(imports.Bytes()), 0644); err != nil {
+		return err
+	}
+	log.Printf("Exported %d resources to '%s'", len(e.resources), outDir)
+	return nil
+}
+
+func apiError(action string, err error, spResp *http.Response) error {
+	return fmt.Errorf("could not %s: %w\n%s", action, err, util.GetBody(spResp))
+}
+
+func diagnosticsError(diagnostics diag.Diagnostics) error {
+	var messages []string
+	for _, item := range diagnostics.Errors() {
+		messages = append(messages, item.Summary()+": "+item.Detail())
+	}
+	return errors.New(strings.Join(messages, "\n"))
+}
@@ -0,0 +1,295 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var plainObjectKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var reservedObjectKeys = []string{"null", "true", "false", "for", "in", "if"}
+
+// references maps object ids to the Terraform expression referencing the exported resource.
+type references map[string]hcl.Traversal
+
+// without returns references excluding the given id, so a resource never references itself.
+func (r references) without(id string) references {
+	if _, ok := r[id]; !ok {
+		return r
+	}
+	result := make(references, len(r))
+	for key, value := range r {
+		if key != id {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+func (r references) stringTokens(value string) hclwrite.Tokens {
+	if traversal, ok := r[value]; ok {
+		return hclwrite.TokensForTraversal(traversal)
+	}
+	return hclwrite.TokensForValue(cty.StringVal(value))
+}
+
+func resourceTraversal(typeName, name string) hcl.Traversal {
+	return hcl.Traversal{
+		hcl.TraverseRoot{Name: typeName},
+		hcl.TraverseAttr{Name: name},
+	}
+}
+
+func resourceAttributeTraversal(typeName, name, attribute string) hcl.Traversal {
+	return append(resourceTraversal(typeName, name), hcl.TraverseAttr{Name: attribute})
+}
+
+// isConfigurable reports whether the attribute belongs to the configuration. Read-only and sensitive attributes are skipped.
+func isConfigurable(attribute schema.Attribute) bool {
+	return (attribute.IsRequired() || attribute.IsOptional()) && !attribute.IsSensitive()
+}
+
+// sortedAttributeNames orders attributes alphabetically, with `name` first for readability.
+func sortedAttributeNames(attributes map[string]schema.Attribute) []string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if names[i] == "name" || names[j] == "name" {
+			return names[i] == "name"
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// writeAttributes renders all configurable, non-null attributes of an object value into the block body.
+func writeAttributes(body *hclwrite.Body, attributes map[string]schema.Attribute, value tftypes.Value, refs references) error {
+	var values map[string]tftypes.Value
+	if err := value.As(&values); err != nil {
+		return err
+	}
+	for _, name := range sortedAttributeNames(attributes) {
+		attribute := attributes[name]
+		attributeValue, ok := values[name]
+		if !ok || !isConfigurable(attribute) || attributeValue.IsNull() || !attributeValue.IsKnown() {
+			continue
+		}
+		tokens, err := attributeTokens(attribute, attributeValue, refs)
+		if err != nil {
+			return fmt.Errorf("attribute '%s': %w", name, err)
+		}
+		body.SetAttributeRaw(name, tokens)
+	}
+	return nil
+}
+
+func attributeTokens(attribute schema.Attribute, value tftypes.Value, refs references) (hclwrite.Tokens, error) {
+	switch typed := attribute.(type) {
+	case schema.SingleNestedAttribute:
+		return objectTokens(typed.Attributes, value, refs)
+	case schema.ListNestedAttribute:
+		return nestedCollectionTokens(typed.NestedObject.Attributes, value, refs)
+	case schema.SetNestedAttribute:
+		return nestedCollectionTokens(typed.NestedObject.Attributes, value, refs)
+	case schema.StringAttribute:
+		switch typed.CustomType.(type) {
+		case jsontypes.ExactType, jsontypes.NormalizedType:
+			return jsonAttributeTokens(value, refs)
+		}
+	}
+	return valueTokens(value, refs)
+}
+
+func objectTokens(attributes map[string]schema.Attribute, value tftypes.Value, refs references) (hclwrite.Tokens, error) {
+	var values map[string]tftypes.Value
+	if err := value.As(&values); err != nil {
+		return nil, err
+	}
+	var items []hclwrite.ObjectAttrTokens
+	for _, name := range sortedAttributeNames(attributes) {
+		attribute := attributes[name]
+		attributeValue, ok := values[name]
+		if !ok || !isConfigurable(attribute) || attributeValue.IsNull() || !attributeValue.IsKnown() {
+			continue
+		}
+		tokens, err := attributeTokens(attribute, attributeValue, refs)
+		if err != nil {
+			return nil, fmt.Errorf("attribute '%s': %w", name, err)
+		}
+		items = append(items, hclwrite.ObjectAttrTokens{
+			Name:  hclwrite.TokensForIdentifier(name),
+			Value: tokens,
+		})
+	}
+	return hclwrite.TokensForObject(items), nil
+}
+
+func nestedCollectionTokens(attributes map[string]schema.Attribute, value tftypes.Value, refs references) (hclwrite.Tokens, error) {
+	var elements []tftypes.Value
+	if err := value.As(&elements); err != nil {
+		return nil, err
+	}
+	items := make([]hclwrite.Tokens, 0, len(elements))
+	for _, element := range elements {
+		tokens, err := objectTokens(attributes, element, refs)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, tokens)
+	}
+	return hclwrite.TokensForTuple(items), nil
+}
+
+// jsonAttributeTokens renders a JSON string attribute as jsonencode() expression, so references can be used inside.
+func jsonAttributeTokens(value tftypes.Value, refs references) (hclwrite.Tokens, error) {
+	var jsonString string
+	if err := value.As(&jsonString); err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewBufferString(jsonString))
+	decoder.UseNumber()
+	var parsed interface{}
+	if err := decoder.Decode(&parsed); err != nil {
+		// Not a valid JSON, keep the raw string
+		return refs.stringTokens(jsonString), nil
+	}
+	tokens, err := jsonTokens(parsed, refs)
+	if err != nil {
+		return nil, err
+	}
+	return hclwrite.TokensForFunctionCall("jsonencode", tokens), nil
+}
+
+func jsonTokens(value interface{}, refs references) (hclwrite.Tokens, error) {
+	switch typed := value.(type) {
+	case nil:
+		return hclwrite.TokensForValue(cty.NullVal(cty.DynamicPseudoType)), nil
+	case string:
+		return refs.stringTokens(typed), nil
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(typed)), nil
+	case json.Number:
+		number, err := cty.ParseNumberVal(typed.String())
+		if err != nil {
+			return nil, err
+		}
+		return hclwrite.TokensForValue(number), nil
+	case []interface{}:
+		items := make([]hclwrite.Tokens, 0, len(typed))
+		for _, item := range typed {
+			tokens, err := jsonTokens(item, refs)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, tokens)
+		}
+		return hclwrite.TokensForTuple(items), nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		items := make([]hclwrite.ObjectAttrTokens, 0, len(keys))
+		for _, key := range keys {
+			tokens, err := jsonTokens(typed[key], refs)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, hclwrite.ObjectAttrTokens{
+				Name:  objectKeyTokens(key),
+				Value: tokens,
+			})
+		}
+		return hclwrite.TokensForObject(items), nil
+	}
+	return nil, fmt.Errorf("unsupported JSON value %v", value)
+}
+
+func valueTokens(value tftypes.Value, refs references) (hclwrite.Tokens, error) {
+	if value.IsNull() {
+		return hclwrite.TokensForValue(cty.NullVal(cty.DynamicPseudoType)), nil
+	}
+	switch value.Type().(type) {
+	case tftypes.List, tftypes.Set, tftypes.Tuple:
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		items := make([]hclwrite.Tokens, 0, len(elements))
+		for _, element := range elements {
+			tokens, err := valueTokens(element, refs)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, tokens)
+		}
+		return hclwrite.TokensForTuple(items), nil
+	case tftypes.Map, tftypes.Object:
+		var values map[string]tftypes.Value
+		if err := value.As(&values); err != nil {
+			return nil, err
+		}
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		items := make([]hclwrite.ObjectAttrTokens, 0, len(keys))
+		for _, key := range keys {
+			tokens, err := valueTokens(values[key], refs)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, hclwrite.ObjectAttrTokens{
+				Name:  objectKeyTokens(key),
+				Value: tokens,
+			})
+		}
+		return hclwrite.TokensForObject(items), nil
+	}
+
+	switch {
+	case value.Type().Is(tftypes.String):
+		var s string
+		if err := value.As(&s); err != nil {
+			return nil, err
+		}
+		return refs.stringTokens(s), nil
+	case value.Type().Is(tftypes.Bool):
+		var b bool
+		if err := value.As(&b); err != nil {
+			return nil, err
+		}
+		return hclwrite.TokensForValue(cty.BoolVal(b)), nil
+	case value.Type().Is(tftypes.Number):
+		n := new(big.Float)
+		if err := value.As(&n); err != nil {
+			return nil, err
+		}
+		return hclwrite.TokensForValue(cty.NumberVal(n)), nil
+	}
+	return nil, fmt.Errorf("unsupported value type %s", value.Type().String())
+}
+
+// objectKeyTokens renders a key of an object. Keys which would be parsed as an expression (e.g. `user-name` or `null`) are quoted.
+func objectKeyTokens(key string) hclwrite.Tokens {
+	if plainObjectKey.MatchString(key) && !slices.Contains(reservedObjectKeys, key) {
+		return hclwrite.TokensForIdentifier(key)
+	}
+	return hclwrite.TokensForValue(cty.StringVal(key))
+}
@@ -0,0 +1,109 @@
+package export
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteAttributes(t *testing.T) {
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"secret": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+			"enabled": schema.BoolAttribute{
+				Optional: true,
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+			"owner": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Required: true,
+					},
+					"type": schema.StringAttribute{
+						Required: true,
+					},
+					"created": schema.StringAttribute{
+						Computed: true,
+					},
+				},
+			},
+			"source_ids": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"attributes": schema.StringAttribute{
+				Optional:   true,
+				CustomType: jsontypes.ExactType{},
+			},
+		},
+	}
+	objectType := testSchema.Type().TerraformType(context.Background()).(tftypes.Object)
+	ownerType := objectType.AttributeTypes["owner"]
+	value := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "self"),
+		"name":        tftypes.NewValue(tftypes.String, "Demo ${name}"),
+		"secret":      tftypes.NewValue(tftypes.String, "s3cr3t"),
+		"enabled":     tftypes.NewValue(tftypes.Bool, true),
+		"description": tftypes.NewValue(tftypes.String, nil),
+		"owner": tftypes.NewValue(ownerType, map[string]tftypes.Value{
+			"id":      tftypes.NewValue(tftypes.String, "2c9180835d191a86015d28455b4a2329"),
+			"type":    tftypes.NewValue(tftypes.String, "IDENTITY"),
+			"created": tftypes.NewValue(tftypes.String, "2024-01-01"),
+		}),
+		"source_ids": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "2c91808568c529c60168cca6f90c1313"),
+			tftypes.NewValue(tftypes.String, "unknown"),
+		}),
+		"attributes": tftypes.NewValue(tftypes.String, `{"sourceId":"2c91808568c529c60168cca6f90c1313","limit":10,"user-name":null}`),
+	})
+	refs := references{
+		"2c91808568c529c60168cca6f90c1313": resourceAttributeTraversal("identitynow_source", "hr", "id"),
+		"self":                             resourceAttributeTraversal("identitynow_source", "self", "id"),
+	}
+
+	file := hclwrite.NewEmptyFile()
+	err := writeAttributes(file.Body(), testSchema.Attributes, value, refs.without("self"))
+	assert.NoError(t, err)
+
+	expected := `name = "Demo $${name}"
+attributes = jsonencode({
+  limit    = 10
+  sourceId = identitynow_source.hr.id
+  "user-name" = null
+})
+enabled = true
+owner = {
+  id   = "2c9180835d191a86015d28455b4a2329"
+  type = "IDENTITY"
+}
+source_ids = [identitynow_source.hr.id, "unknown"]
+`
+	assert.Equal(t, string(hclwrite.Format([]byte(expected))), string(hclwrite.Format(file.Bytes())))
+}
+
+func TestUniqueName(t *testing.T) {
+	e := newExporter(nil)
+	assert.Equal(t, "active_directory", e.uniqueName(typeSource, "Active Directory"))
+	assert.Equal(t, "active_directory_2", e.uniqueName(typeSource, "Active-Directory"))
+	assert.Equal(t, "active_directory", e.uniqueName(typeSourceSchema, "Active Directory"))
+	assert.Equal(t, "_1st_source", e.uniqueName(typeSource, "1st Source"))
+	assert.Equal(t, "transform", e.uniqueName(typeTransform, "???"))
+}
@@ -3,11 +3,14 @@ package identity_attribute
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 	"terraform-provider-identitynow/internal/sailpoint/custom"
 	"terraform-provider-identitynow/internal/util"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
@@ -19,8 +22,9 @@ import (
 )
 
 var (
-	_ resource.Resource              = &identityAttributeResource{}
-	_ resource.ResourceWithConfigure = &identityAttributeResource{}
+	_ resource.Resource                = &identityAttributeResource{}
+	_ resource.ResourceWithConfigure   = &identityAttributeResource{}
+	_ resource.ResourceWithImportState = &identityAttributeResource{}
 )
 
 func NewIdentityAttributeResource() resource.Resource {
@@ -145,6 +149,8 @@ func (r *identityAttributeResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
+	resp.Diagnostics.Append(util.MarkCreated(ctx, resp.Private)...)
+
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
@@ -158,8 +164,10 @@ func (r *identityAttributeResource) Read(ctx context.Context, req resource.ReadR
 	}
 
 	name := state.Name.ValueString()
-	identityAttribute, spResp, err := r.apiClient.Beta.IdentityAttributesAPI.GetIdentityAttribute(ctx, name).Execute()
-	if spResp.StatusCode == 404 {
+	identityAttribute, spResp, err := util.ReadAfterWrite(ctx, req.Private, func() (*sailpoint_beta.IdentityAttribute, *http.Response, error) {
+		return r.apiClient.Beta.IdentityAttributesAPI.GetIdentityAttribute(ctx, name).Execute()
+	})
+	if util.IsNotFound(spResp) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -277,3 +285,9 @@ func (r *identityAttributeResource) mapToTerraformModel(tfModel *identityAttribu
 		}
 	}
 }
+
+func (r *identityAttributeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Identity Attributes are identified by their technical name
+	name := strings.TrimPrefix(req.ID, "identity_attribute:")
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), resource.ImportStateRequest{ID: name}, resp)
+}
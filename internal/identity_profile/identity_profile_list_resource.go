@@ -0,0 +1,67 @@
+package identity_profile
+
+import (
+	"context"
+	"terraform-provider-identitynow/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v2"
+	sailpoint_beta "github.com/sailpoint-oss/golang-sdk/v2/api_beta"
+)
+
+// Implementation of IdentityNow Identity Profile listing for `terraform query` - https://developer.sailpoint.com/idn/api/beta/list-identity-profiles
+var (
+	_ list.ListResource              = &identityProfileListResource{}
+	_ list.ListResourceWithConfigure = &identityProfileListResource{}
+)
+
+func NewIdentityProfileListResource() list.ListResource {
+	return &identityProfileListResource{}
+}
+
+// identityProfileListResource shares Configure, Metadata and the state mapping with the managed resource.
+type identityProfileListResource struct {
+	identityProfileResource
+}
+
+func (r *identityProfileListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = util.ListFiltersSchema(`name sw "Employees"`)
+}
+
+func (r *identityProfileListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config util.ListFiltersModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	listRequest := r.apiClient.Beta.IdentityProfilesAPI.ListIdentityProfiles(ctx)
+	if !config.Filters.IsNull() {
+		listRequest = listRequest.Filters(config.Filters.ValueString())
+	}
+	identityProfiles, spResp, err := sailpoint.PaginateWithDefaults[sailpoint_beta.IdentityProfile](listRequest)
+	if err != nil {
+		diags.AddError(
+			"Error Listing Identity Profiles",
+			"Could not list Identity Profiles: "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = util.StreamListResults(ctx, req, identityProfiles, func(identityProfile *sailpoint_beta.IdentityProfile, result *list.ListResult) {
+		result.DisplayName = identityProfile.Name
+		util.SetIdResourceIdentity(ctx, result.Identity, types.StringPointerValue(identityProfile.Id), &result.Diagnostics)
+		if !req.IncludeResource {
+			return
+		}
+		var model identityProfileModel
+		r.mapToTerraformModel(&model, identityProfile, &result.Diagnostics)
+		if result.Diagnostics.HasError() {
+			return
+		}
+		result.Diagnostics.Append(result.Resource.Set(ctx, &model)...)
+	})
+}
@@ -3,6 +3,7 @@ package identity_profile
 import (
 	"context"
 	"fmt"
+	"net/http"
 	patch "terraform-provider-identitynow/internal/patch"
 	"terraform-provider-identitynow/internal/sailpoint/custom"
 	"terraform-provider-identitynow/internal/util"
@@ -24,8 +25,10 @@ import (
 // Implementation of IdentityNow Identity Profiles CRUD - https://developer.sailpoint.com/idn/api/beta/identity-profiles
 
 var (
-	_ resource.Resource              = &identityProfileResource{}
-	_ resource.ResourceWithConfigure = &identityProfileResource{}
+	_ resource.Resource                = &identityProfileResource{}
+	_ resource.ResourceWithConfigure   = &identityProfileResource{}
+	_ resource.ResourceWithImportState = &identityProfileResource{}
+	_ resource.ResourceWithIdentity    = &identityProfileResource{}
 )
 
 func NewIdentityProfileResource() resource.Resource {
@@ -56,6 +59,10 @@ func (r *identityProfileResource) Metadata(ctx context.Context, req resource.Met
 	resp.TypeName = req.ProviderTypeName + "_identity_profile"
 }
 
+func (r *identityProfileResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = util.IdResourceIdentitySchema()
+}
+
 func (r *identityProfileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
@@ -163,6 +170,10 @@ func (r *identityProfileResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	util.SetIdResourceIdentity(ctx, resp.Identity, plan.Id, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(util.MarkCreated(ctx, resp.Private)...)
+
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
@@ -173,9 +184,12 @@ func (r *identityProfileResource) Read(ctx context.Context, req resource.ReadReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	util.SetIdResourceIdentity(ctx, resp.Identity, state.Id, &resp.Diagnostics)
 
-	identityProfile, spResp, err := r.apiClient.Beta.IdentityProfilesAPI.GetIdentityProfile(ctx, state.Id.ValueString()).Execute()
-	if spResp.StatusCode == 404 {
+	identityProfile, spResp, err := util.ReadAfterWrite(ctx, req.Private, func() (*sailpoint_beta.IdentityProfile, *http.Response, error) {
+		return r.apiClient.Beta.IdentityProfilesAPI.GetIdentityProfile(ctx, state.Id.ValueString()).Execute()
+	})
+	if util.IsNotFound(spResp) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -238,6 +252,8 @@ func (r *identityProfileResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
+	util.SetIdResourceIdentity(ctx, resp.Identity, plan.Id, &resp.Diagnostics)
+
 	// Set refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -347,3 +363,30 @@ func (r *identityProfileResource) mapToTerraformModel(tfModel *identityProfileMo
 		}
 	}
 }
+
+func (r *identityProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	key := util.ParseImportKey(util.GetImportId(ctx, req, &resp.Diagnostics), "identity_profile")
+	var identityProfile *sailpoint_beta.IdentityProfile
+	var spResp *http.Response
+	var err error
+	if key.IsName() {
+		identityProfile, spResp, err = util.FindIdentityProfileByName(ctx, r.apiClient, key.Name)
+	} else {
+		identityProfile, spResp, err = r.apiClient.Beta.IdentityProfilesAPI.GetIdentityProfile(ctx, key.Id).Execute()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Identity Profile",
+			"Could not find Identity Profile '"+key.String()+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+
+	var state identityProfileModel
+	r.mapToTerraformModel(&state, identityProfile, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	util.SetIdResourceIdentity(ctx, resp.Identity, state.Id, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
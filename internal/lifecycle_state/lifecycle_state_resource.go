@@ -3,6 +3,7 @@ package lifecycle_state
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"terraform-provider-identitynow/internal/patch"
 	"terraform-provider-identitynow/internal/sailpoint/custom"
 	"terraform-provider-identitynow/internal/util"
@@ -10,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -24,8 +26,9 @@ import (
 )
 
 var (
-	_ resource.Resource              = &lifeCycleResource{}
-	_ resource.ResourceWithConfigure = &lifeCycleResource{}
+	_ resource.Resource                = &lifeCycleResource{}
+	_ resource.ResourceWithConfigure   = &lifeCycleResource{}
+	_ resource.ResourceWithImportState = &lifeCycleResource{}
 )
 
 func NewLifecycleStateResource() resource.Resource {
@@ -173,6 +176,10 @@ func (r *lifeCycleResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	existing := r.findExisting(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	created := false
 	if existing == nil {
 		lifecycleState := r.convertToAPIModel(&plan, &resp.Diagnostics, true)
 		tflog.Info(ctx, fmt.Sprintf("Creating LifeCycle State in Identity Profile '%s': %s", identityProfileId, util.PrettyPrint(lifecycleState)))
@@ -185,6 +192,7 @@ func (r *lifeCycleResource) Create(ctx context.Context, req resource.CreateReque
 			return
 		}
 		existing = lifecycleStateResp
+		created = true
 	}
 
 	lifecycleState := r.convertToAPIModel(&plan, &resp.Diagnostics, false)
@@ -194,6 +202,7 @@ func (r *lifeCycleResource) Create(ctx context.Context, req resource.CreateReque
 			"Error Generating Update Patch",
 			"Could not generate update patch for Lifecycle State '"+plan.Name.ValueString()+"': "+err.Error(),
 		)
+		r.savePartiallyCreated(ctx, &plan, existing, created, resp)
 		return
 	}
 	v3JsonPatch, err := patch.ConvertPatchOperationFromBetaToV3(jsonPatch)
@@ -202,6 +211,7 @@ func (r *lifeCycleResource) Create(ctx context.Context, req resource.CreateReque
 			"Error Generating Update Patch",
 			"Could not convert patch to V3 for Lifecycle State '"+plan.Name.ValueString()+"': "+err.Error(),
 		)
+		r.savePartiallyCreated(ctx, &plan, existing, created, resp)
 		return
 	}
 	if len(v3JsonPatch) > 0 {
@@ -212,6 +222,7 @@ func (r *lifeCycleResource) Create(ctx context.Context, req resource.CreateReque
 				"Error Updating Lifecycle State",
 				"Could not update Lifecycle State '"+plan.Name.ValueString()+"': "+err.Error()+"\n"+util.GetBody(spResp),
 			)
+			r.savePartiallyCreated(ctx, &plan, existing, created, resp)
 			return
 		}
 		existing = lifecycleStateResp
@@ -223,10 +234,28 @@ func (r *lifeCycleResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	resp.Diagnostics.Append(util.MarkCreated(ctx, resp.Private)...)
+
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
+// savePartiallyCreated keeps a Lifecycle State created by this Create in state after a failed update, so Terraform taints it
+// instead of losing track of it. An adopted existing Lifecycle State is not saved, so it is not replaced.
+func (r *lifeCycleResource) savePartiallyCreated(ctx context.Context, plan *lifecycleStateModel, lifecycleState *sailpointV3.LifecycleState, created bool, resp *resource.CreateResponse) {
+	if !created {
+		return
+	}
+	var diagnostics diag.Diagnostics
+	r.mapToTerraformModel(plan, lifecycleState, &diagnostics)
+	diagnostics.Append(util.MarkCreated(ctx, resp.Private)...)
+	diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(diagnostics...)
+	if !diagnostics.HasError() {
+		util.AddPartiallyCreatedWarning(&resp.Diagnostics, "Lifecycle State", plan.Id.ValueString())
+	}
+}
+
 func (r *lifeCycleResource) findExisting(ctx context.Context, plan *lifecycleStateModel, diagnostics *diag.Diagnostics) *sailpointV3.LifecycleState {
 	identityProfileId := plan.IdentityProfileId.ValueString()
 	lifecycleStateResp, spResp, err := r.apiClient.V3.LifecycleStatesAPI.GetLifecycleStates(ctx, identityProfileId).Execute()
@@ -252,8 +281,10 @@ func (r *lifeCycleResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	lifecycleState, spResp, err := r.apiClient.V3.LifecycleStatesAPI.GetLifecycleState(ctx, state.IdentityProfileId.ValueString(), state.Id.ValueString()).Execute()
-	if spResp.StatusCode == 404 {
+	lifecycleState, spResp, err := util.ReadAfterWrite(ctx, req.Private, func() (*sailpointV3.LifecycleState, *http.Response, error) {
+		return r.apiClient.V3.LifecycleStatesAPI.GetLifecycleState(ctx, state.IdentityProfileId.ValueString(), state.Id.ValueString()).Execute()
+	})
+	if util.IsNotFound(spResp) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -419,12 +450,58 @@ func (r *lifeCycleResource) mapToTerraformModel(model *lifecycleStateModel, life
 			}
 		}
 	}
-	if len(lifecycleState.AccessProfileIds) > 0 {
-		model.AccessProfileIds = make([]types.String, len(lifecycleState.AccessProfileIds))
-		for i, v := range lifecycleState.AccessProfileIds {
-			model.AccessProfileIds[i] = types.StringValue(v)
-		}
+	model.AccessProfileIds = make([]types.String, len(lifecycleState.AccessProfileIds))
+	for i, v := range lifecycleState.AccessProfileIds {
+		model.AccessProfileIds[i] = types.StringValue(v)
 	}
 	model.IdentityState = types.StringPointerValue(lifecycleState.IdentityState.Get())
 
 }
+
+func (r *lifeCycleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	profilePart, statePart, err := util.SplitImportId(req.ID, "<identityProfileId|identityProfileName>/<lifecycleStateId|technicalName>")
+	if err != nil {
+		resp.Diagnostics.AddError("Error Importing Lifecycle State", err.Error())
+		return
+	}
+	profileKey := util.ParseImportKey(profilePart, "identity_profile")
+	identityProfileId := profileKey.Id
+	if profileKey.IsName() {
+		identityProfile, spResp, err := util.FindIdentityProfileByName(ctx, r.apiClient, profileKey.Name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Lifecycle State",
+				"Could not find Identity Profile '"+profileKey.Name+"': "+err.Error()+"\n"+util.GetBody(spResp),
+			)
+			return
+		}
+		identityProfileId = *identityProfile.Id
+	}
+	lifecycleStateId := statePart
+	if !util.IsObjectId(statePart) {
+		lifecycleStates, spResp, err := r.apiClient.V3.LifecycleStatesAPI.GetLifecycleStates(ctx, identityProfileId).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Lifecycle State",
+				"Error during listing of Lifecycle State of '"+identityProfileId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+			)
+			return
+		}
+		lifecycleStateId = ""
+		for _, lifecycleState := range lifecycleStates {
+			if lifecycleState.TechnicalName == statePart {
+				lifecycleStateId = *lifecycleState.Id
+				break
+			}
+		}
+		if lifecycleStateId == "" {
+			resp.Diagnostics.AddError(
+				"Error Importing Lifecycle State",
+				"Could not find Lifecycle State '"+statePart+"' in Identity Profile '"+profilePart+"'",
+			)
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), lifecycleStateId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("identity_profile_id"), identityProfileId)...)
+}
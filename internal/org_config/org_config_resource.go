@@ -17,8 +17,9 @@ import (
 )
 
 var (
-	_ resource.Resource              = &orgConfigResource{}
-	_ resource.ResourceWithConfigure = &orgConfigResource{}
+	_ resource.Resource                = &orgConfigResource{}
+	_ resource.ResourceWithConfigure   = &orgConfigResource{}
+	_ resource.ResourceWithImportState = &orgConfigResource{}
 )
 
 const (
@@ -180,3 +181,12 @@ func (r *orgConfigResource) convertToAPIModel(tfModelToConvert *orgConfigModel,
 		TimeZone: tfModelToConvert.TimeZone.ValueStringPointer(),
 	}
 }
+
+func (r *orgConfigResource) ImportState(ctx context.Context, _ resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Org Config is a singleton, so any import identifier refers to the tenant configuration
+	state := r.doRead(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
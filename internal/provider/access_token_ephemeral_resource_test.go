@@ -0,0 +1,47 @@
+//go:build !integration
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccessTokenEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		// Ephemeral resources are only available in Terraform 1.10 and later
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"identitynow": providerserver.NewProtocol6WithError(New("test")()),
+			"echo":        echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			// Open testing
+			{
+				Config: providerConfig + `
+ephemeral "identitynow_access_token" "test" {}
+
+provider "echo" {
+  data = ephemeral.identitynow_access_token.test
+}
+
+resource "echo" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.access_token", "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9."),
+					resource.TestCheckResourceAttr("echo.test", "data.token_type", "bearer"),
+					resource.TestCheckResourceAttr("echo.test", "data.base_url", "http://localhost:3000"),
+					resource.TestMatchResourceAttr("echo.test", "data.expires_at", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`)),
+				),
+			},
+		},
+	})
+}
@@ -7,9 +7,8 @@ import (
 	"testing"
 )
 
-func TestIntegration_SourceAggregationScheduleResource_AccountToEntitlement(t *testing.T) {
-	t.Skip("aggregation API was disabled by SailPoint")
-	sourceCloud := getSources(1, "")[0].ConnectorAttributes["cloudExternalId"].(string)
+func TestIntegration_SourceAggregationScheduleResource_AccountToGroup(t *testing.T) {
+	sourceId := *getSources(1, "")[0].Id
 
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
@@ -17,30 +16,30 @@ func TestIntegration_SourceAggregationScheduleResource_AccountToEntitlement(t *t
 			{
 				Config: providerIntegrationConfig + `
 resource "identitynow_source_aggregation_schedule" "test_account_schedule" {
-  source_cloud_id  = ` + sourceCloud + `
-  cron_expression  = "0 5 0 * * ?"
-  aggregation_type = "account"
+  source_id       = "` + sourceId + `"
+  cron_expression = "0 5 0 * * ?"
+  type            = "ACCOUNT_AGGREGATION"
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "source_cloud_id", sourceCloud),
+					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "source_id", sourceId),
 					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "cron_expression", "0 5 0 * * ?"),
-					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "aggregation_type", "account"),
+					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "type", "ACCOUNT_AGGREGATION"),
 				),
 			},
 			// Update and Read testing
 			{
 				Config: providerIntegrationConfig + `
 resource "identitynow_source_aggregation_schedule" "test_account_schedule" {
-  source_cloud_id  = ` + sourceCloud + `
-  cron_expression  = "0 4 0 * * ?"
-  aggregation_type = "entitlement"
+  source_id       = "` + sourceId + `"
+  cron_expression = "0 4 0 * * ?"
+  type            = "GROUP_AGGREGATION"
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "source_cloud_id", sourceCloud),
+					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "source_id", sourceId),
 					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "cron_expression", "0 4 0 * * ?"),
-					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "aggregation_type", "entitlement"),
+					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "type", "GROUP_AGGREGATION"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -48,9 +47,8 @@ resource "identitynow_source_aggregation_schedule" "test_account_schedule" {
 	})
 }
 
-func TestIntegration_SourceAggregationScheduleResource_EntitlementToAccount(t *testing.T) {
-	t.Skip("aggregation API was disabled by SailPoint")
-	sourceCloud := getSources(1, "")[0].ConnectorAttributes["cloudExternalId"].(string)
+func TestIntegration_SourceAggregationScheduleResource_GroupToAccount(t *testing.T) {
+	sourceId := *getSources(1, "")[0].Id
 
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
@@ -59,30 +57,30 @@ func TestIntegration_SourceAggregationScheduleResource_EntitlementToAccount(t *t
 			{
 				Config: providerIntegrationConfig + `
 resource "identitynow_source_aggregation_schedule" "test_account_schedule" {
-  source_cloud_id  = ` + sourceCloud + `
-  cron_expression  = "0 5 0 * * ?"
-  aggregation_type = "entitlement"
+  source_id       = "` + sourceId + `"
+  cron_expression = "0 5 0 * * ?"
+  type            = "GROUP_AGGREGATION"
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "source_cloud_id", sourceCloud),
+					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "source_id", sourceId),
 					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "cron_expression", "0 5 0 * * ?"),
-					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "aggregation_type", "entitlement"),
+					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "type", "GROUP_AGGREGATION"),
 				),
 			},
 			// Update and Read testing
 			{
 				Config: providerIntegrationConfig + `
 resource "identitynow_source_aggregation_schedule" "test_account_schedule" {
-  source_cloud_id  = ` + sourceCloud + `
-  cron_expression  = "0 4 0 * * ?"
-  aggregation_type = "account"
+  source_id       = "` + sourceId + `"
+  cron_expression = "0 4 0 * * ?"
+  type            = "ACCOUNT_AGGREGATION"
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "source_cloud_id", sourceCloud),
+					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "source_id", sourceId),
 					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "cron_expression", "0 4 0 * * ?"),
-					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "aggregation_type", "account"),
+					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "type", "ACCOUNT_AGGREGATION"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -91,10 +89,9 @@ resource "identitynow_source_aggregation_schedule" "test_account_schedule" {
 }
 
 func TestIntegration_SourceAggregationScheduleResource_AccountSourceAndCronUpdate(t *testing.T) {
-	t.Skip("aggregation API was disabled by SailPoint")
-	sourceCloud := getSources(2, "")
-	originalSourceCloudId := sourceCloud[0].ConnectorAttributes["cloudExternalId"].(string)
-	updatedSourceCloudId := sourceCloud[1].ConnectorAttributes["cloudExternalId"].(string)
+	sources := getSources(2, "")
+	originalSourceId := *sources[0].Id
+	updatedSourceId := *sources[1].Id
 
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
@@ -103,30 +100,30 @@ func TestIntegration_SourceAggregationScheduleResource_AccountSourceAndCronUpdat
 			{
 				Config: providerIntegrationConfig + `
 resource "identitynow_source_aggregation_schedule" "test_account_schedule" {
-  source_cloud_id  = ` + originalSourceCloudId + `
-  cron_expression  = "0 5 0 * * ?"
-  aggregation_type = "account"
+  source_id       = "` + originalSourceId + `"
+  cron_expression = "0 5 0 * * ?"
+  type            = "ACCOUNT_AGGREGATION"
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "source_cloud_id", originalSourceCloudId),
+					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "source_id", originalSourceId),
 					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "cron_expression", "0 5 0 * * ?"),
-					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "aggregation_type", "account"),
+					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "type", "ACCOUNT_AGGREGATION"),
 				),
 			},
 			// Update and Read testing
 			{
 				Config: providerIntegrationConfig + `
 resource "identitynow_source_aggregation_schedule" "test_account_schedule" {
-  source_cloud_id  = ` + updatedSourceCloudId + `
-  cron_expression  = "0 4 0 * * ?"
-  aggregation_type = "account"
+  source_id       = "` + updatedSourceId + `"
+  cron_expression = "0 4 0 * * ?"
+  type            = "ACCOUNT_AGGREGATION"
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "source_cloud_id", updatedSourceCloudId),
+					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "source_id", updatedSourceId),
 					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "cron_expression", "0 4 0 * * ?"),
-					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "aggregation_type", "account"),
+					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "type", "ACCOUNT_AGGREGATION"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -134,11 +131,10 @@ resource "identitynow_source_aggregation_schedule" "test_account_schedule" {
 	})
 }
 
-func TestIntegration_SourceAggregationScheduleResource_EntitlementSourceAndCronUpdate(t *testing.T) {
-	t.Skip("aggregation API was disabled by SailPoint")
-	sourceCloud := getSources(2, "")
-	originalSourceCloudId := sourceCloud[0].ConnectorAttributes["cloudExternalId"].(string)
-	updatedSourceCloudId := sourceCloud[1].ConnectorAttributes["cloudExternalId"].(string)
+func TestIntegration_SourceAggregationScheduleResource_GroupSourceAndCronUpdate(t *testing.T) {
+	sources := getSources(2, "")
+	originalSourceId := *sources[0].Id
+	updatedSourceId := *sources[1].Id
 
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
@@ -147,30 +143,30 @@ func TestIntegration_SourceAggregationScheduleResource_EntitlementSourceAndCronU
 			{
 				Config: providerIntegrationConfig + `
 resource "identitynow_source_aggregation_schedule" "test_account_schedule" {
-  source_cloud_id  = ` + originalSourceCloudId + `
-  cron_expression  = "0 5 0 * * ?"
-  aggregation_type = "entitlement"
+  source_id       = "` + originalSourceId + `"
+  cron_expression = "0 5 0 * * ?"
+  type            = "GROUP_AGGREGATION"
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "source_cloud_id", originalSourceCloudId),
+					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "source_id", originalSourceId),
 					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "cron_expression", "0 5 0 * * ?"),
-					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "aggregation_type", "entitlement"),
+					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "type", "GROUP_AGGREGATION"),
 				),
 			},
 			// Update and Read testing
 			{
 				Config: providerIntegrationConfig + `
 resource "identitynow_source_aggregation_schedule" "test_account_schedule" {
-  source_cloud_id  = ` + updatedSourceCloudId + `
-  cron_expression  = "0 4 0 * * ?"
-  aggregation_type = "entitlement"
+  source_id       = "` + updatedSourceId + `"
+  cron_expression = "0 4 0 * * ?"
+  type            = "GROUP_AGGREGATION"
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "source_cloud_id", updatedSourceCloudId),
+					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "source_id", updatedSourceId),
 					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "cron_expression", "0 4 0 * * ?"),
-					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "aggregation_type", "entitlement"),
+					resource.TestCheckResourceAttr("identitynow_source_aggregation_schedule.test_account_schedule", "type", "GROUP_AGGREGATION"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
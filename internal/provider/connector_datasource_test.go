@@ -19,6 +19,12 @@ func TestConnectorDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.identitynow_connector.test", "name", "ADAM"),
 					resource.TestCheckResourceAttr("data.identitynow_connector.test", "type", "ADAM - Direct"),
 					resource.TestCheckResourceAttr("data.identitynow_connector.test", "script_name", "adam-angularsc"),
+					resource.TestCheckResourceAttr("data.identitynow_connector.test", "attributes.#", "4"),
+					resource.TestCheckResourceAttr("data.identitynow_connector.test", "attributes.0.key", "host"),
+					resource.TestCheckResourceAttr("data.identitynow_connector.test", "attributes.0.required", "true"),
+					resource.TestCheckResourceAttr("data.identitynow_connector.test", "attributes.1.type", "number"),
+					resource.TestCheckResourceAttr("data.identitynow_connector.test", "attributes.2.required", "false"),
+					resource.TestCheckResourceAttr("data.identitynow_connector.test", "attributes.3.type", "secret"),
 				),
 			},
 		},
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"terraform-provider-identitynow/internal/access_token"
 	"terraform-provider-identitynow/internal/cluster"
 	"terraform-provider-identitynow/internal/connector"
 	"terraform-provider-identitynow/internal/connector_rule"
@@ -14,12 +15,19 @@ import (
 	"terraform-provider-identitynow/internal/role"
 	"terraform-provider-identitynow/internal/sailpoint/custom"
 	"terraform-provider-identitynow/internal/source"
+	"terraform-provider-identitynow/internal/source_aggregation"
 	"terraform-provider-identitynow/internal/source_aggregation_schedule"
+	"terraform-provider-identitynow/internal/source_correlation_config"
+	"terraform-provider-identitynow/internal/source_provisioning_policy"
 	"terraform-provider-identitynow/internal/source_schema"
 	"terraform-provider-identitynow/internal/transform"
 	"terraform-provider-identitynow/internal/workflow"
+	"terraform-provider-identitynow/internal/workflow_execution"
+	"terraform-provider-identitynow/internal/workflow_library"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -28,13 +36,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"os"
-
-	sailpoint "github.com/sailpoint-oss/golang-sdk/v2"
 )
 
 // Ensure identityNowProvider satisfies various provider interfaces.
 var (
-	_ provider.Provider = &identityNowProvider{}
+	_ provider.Provider                       = &identityNowProvider{}
+	_ provider.ProviderWithListResources      = &identityNowProvider{}
+	_ provider.ProviderWithEphemeralResources = &identityNowProvider{}
 )
 
 func New(version string) func() provider.Provider {
@@ -185,18 +193,11 @@ func (p *identityNowProvider) Configure(ctx context.Context, req provider.Config
 		return
 	}
 
-	configuration := sailpoint.NewConfiguration(sailpoint.ClientConfiguration{
-		ClientId:     clientId,
-		ClientSecret: clientSecret,
-		BaseURL:      host,
-		TokenURL:     host + "/oauth/token",
-	})
-
-	apiClient := sailpoint.NewAPIClient(configuration)
-	configuration.HTTPClient.RetryMax = 5
-	client := custom.NewAPIClient(apiClient, configuration)
+	client := custom.NewAPIClientForTenant(host, clientId, clientSecret)
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.ListResourceData = client
+	resp.EphemeralResourceData = client
 }
 
 func (p *identityNowProvider) Resources(_ context.Context) []func() resource.Resource {
@@ -206,20 +207,46 @@ func (p *identityNowProvider) Resources(_ context.Context) []func() resource.Res
 		source.NewSourceResource,
 		identity_profile.NewIdentityProfileResource,
 		source_schema.NewSourceSchemaResource,
+		source_correlation_config.NewSourceCorrelationConfigResource,
+		source_provisioning_policy.NewSourceProvisioningPolicyResource,
 		source_aggregation_schedule.NewSourceAggregationScheduleResource,
+		source_aggregation.NewSourceAggregationResource,
 		lifecycle_state.NewLifecycleStateResource,
 		connector_rule.NewConnectorRuleResource,
 		workflow.NewWorkflowResource,
+		workflow.NewWorkflowExternalTriggerResource,
 		role.NewRoleResource,
 		org_config.NewOrgConfigResource,
 	}
 }
 
+func (p *identityNowProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		transform.NewTransformListResource,
+		source.NewSourceListResource,
+		identity_profile.NewIdentityProfileListResource,
+		connector_rule.NewConnectorRuleListResource,
+		workflow.NewWorkflowListResource,
+		role.NewRoleListResource,
+	}
+}
+
+func (p *identityNowProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		access_token.NewAccessTokenEphemeralResource,
+	}
+}
+
 func (p *identityNowProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		identity.NewIdentityDataSource,
 		cluster.NewClusterDataSource,
 		connector.NewConnectorDataSource,
+		source_schema.NewSourceSchemaDataSource,
 		entitlement.NewEntitlementDataSource,
+		workflow_execution.NewWorkflowExecutionsDataSource,
+		workflow_library.NewWorkflowActionsDataSource,
+		workflow_library.NewWorkflowTriggersDataSource,
+		workflow_library.NewWorkflowOperatorsDataSource,
 	}
 }
@@ -0,0 +1,74 @@
+//go:build integration
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"testing"
+)
+
+func TestIntegration_SourceCorrelationConfigResource_AddNew(t *testing.T) {
+	checkForPendingCisTask(context.Background())
+	sourceCloudId := *getSources(1, "")[0].Id
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerIntegrationConfig + `
+resource "identitynow_source_correlation_config" "test" {
+  source_id = "` + sourceCloudId + `"
+  attribute_assignments = [
+    {
+      identity_attribute = "email"
+      account_attribute  = "mail"
+    }
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("identitynow_source_correlation_config.test", "source_id", sourceCloudId),
+					resource.TestCheckResourceAttrSet("identitynow_source_correlation_config.test", "id"),
+					resource.TestCheckResourceAttr("identitynow_source_correlation_config.test", "attribute_assignments.#", "1"),
+					resource.TestCheckResourceAttr("identitynow_source_correlation_config.test", "attribute_assignments.0.identity_attribute", "email"),
+					resource.TestCheckResourceAttr("identitynow_source_correlation_config.test", "attribute_assignments.0.account_attribute", "mail"),
+					resource.TestCheckResourceAttr("identitynow_source_correlation_config.test", "attribute_assignments.0.operation", "EQ"),
+					resource.TestCheckResourceAttr("identitynow_source_correlation_config.test", "attribute_assignments.0.complex", "false"),
+					resource.TestCheckResourceAttr("identitynow_source_correlation_config.test", "attribute_assignments.0.ignore_case", "false"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerIntegrationConfig + `
+resource "identitynow_source_correlation_config" "test" {
+  source_id = "` + sourceCloudId + `"
+  attribute_assignments = [
+    {
+      identity_attribute = "email"
+      account_attribute  = "mail"
+      ignore_case        = true
+    },
+    {
+      identity_attribute = "uid"
+      account_attribute  = "sAMAccountName"
+      complex            = true
+      match_mode         = "START"
+    }
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("identitynow_source_correlation_config.test", "attribute_assignments.#", "2"),
+					resource.TestCheckResourceAttr("identitynow_source_correlation_config.test", "attribute_assignments.0.ignore_case", "true"),
+					resource.TestCheckResourceAttr("identitynow_source_correlation_config.test", "attribute_assignments.1.identity_attribute", "uid"),
+					resource.TestCheckResourceAttr("identitynow_source_correlation_config.test", "attribute_assignments.1.account_attribute", "sAMAccountName"),
+					resource.TestCheckResourceAttr("identitynow_source_correlation_config.test", "attribute_assignments.1.complex", "true"),
+					resource.TestCheckResourceAttr("identitynow_source_correlation_config.test", "attribute_assignments.1.match_mode", "START"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,88 @@
+//go:build integration
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"testing"
+)
+
+func TestIntegration_SourceProvisioningPolicyResource_AddNew(t *testing.T) {
+	checkForPendingCisTask(context.Background())
+	sourceId := *getSources(1, "")[0].Id
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerIntegrationConfig + `
+resource "identitynow_source_provisioning_policy" "test" {
+  source_id  = "` + sourceId + `"
+  usage_type = "CREATE"
+  name       = "Account"
+  fields = [
+    {
+      name        = "id"
+      is_required = true
+      transform = jsonencode({
+        type = "identityAttribute"
+        attributes = {
+          name = "uid"
+        }
+      })
+    }
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("identitynow_source_provisioning_policy.test", "source_id", sourceId),
+					resource.TestCheckResourceAttr("identitynow_source_provisioning_policy.test", "usage_type", "CREATE"),
+					resource.TestCheckResourceAttr("identitynow_source_provisioning_policy.test", "name", "Account"),
+					resource.TestCheckResourceAttr("identitynow_source_provisioning_policy.test", "fields.0.name", "id"),
+					resource.TestCheckResourceAttr("identitynow_source_provisioning_policy.test", "fields.0.type", "string"),
+					resource.TestCheckResourceAttr("identitynow_source_provisioning_policy.test", "fields.0.is_required", "true"),
+					resource.TestCheckResourceAttr("identitynow_source_provisioning_policy.test", "fields.0.is_multi_valued", "false"),
+					resource.TestCheckResourceAttr("identitynow_source_provisioning_policy.test", "fields.0.transform", "{\"attributes\":{\"name\":\"uid\"},\"type\":\"identityAttribute\"}"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerIntegrationConfig + `
+resource "identitynow_source_provisioning_policy" "test" {
+  source_id   = "` + sourceId + `"
+  usage_type  = "CREATE"
+  name        = "Account"
+  description = "Account create policy"
+  fields = [
+    {
+      name        = "id"
+      is_required = true
+      transform = jsonencode({
+        type = "identityAttribute"
+        attributes = {
+          name = "uid"
+        }
+      })
+    },
+    {
+      name            = "groups"
+      is_multi_valued = true
+    }
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("identitynow_source_provisioning_policy.test", "description", "Account create policy"),
+					resource.TestCheckResourceAttr("identitynow_source_provisioning_policy.test", "fields.#", "2"),
+					resource.TestCheckResourceAttr("identitynow_source_provisioning_policy.test", "fields.1.name", "groups"),
+					resource.TestCheckResourceAttr("identitynow_source_provisioning_policy.test", "fields.1.is_required", "false"),
+					resource.TestCheckResourceAttr("identitynow_source_provisioning_policy.test", "fields.1.is_multi_valued", "true"),
+					resource.TestCheckNoResourceAttr("identitynow_source_provisioning_policy.test", "fields.1.transform"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -58,12 +58,14 @@ resource "identitynow_source_schema" "test" {
 					resource.TestCheckResourceAttr("identitynow_source_schema.test", "features.0", "AUTHENTICATE"),
 					resource.TestCheckResourceAttr("identitynow_source_schema.test", "configuration", "{\"groupMemberAttribute\":\"member\"}"),
 
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.name", "id"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.type", "STRING"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.description", "Id of the user"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.is_multi", "false"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.is_entitlement", "false"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.is_group", "false"),
+					resource.TestCheckTypeSetElemNestedAttrs("identitynow_source_schema.test", "attributes.*", map[string]string{
+						"name":           "id",
+						"type":           "STRING",
+						"description":    "Id of the user",
+						"is_multi":       "false",
+						"is_entitlement": "false",
+						"is_group":       "false",
+					}),
 					//resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.schema.type", "CONNECTOR_SCHEMA"),
 					//resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.schema.id", "yes"),
 					//resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.schema.name", "name"),
@@ -127,22 +129,26 @@ resource "identitynow_source_schema" "test" {
 					resource.TestCheckResourceAttr("identitynow_source_schema.test", "features.1", "ENABLE"),
 					resource.TestCheckResourceAttr("identitynow_source_schema.test", "configuration", "{\"groupMemberAttribute\":\"id\"}"),
 
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.name", "id"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.type", "STRING"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.description", "ID of the user Upd"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.is_multi", "false"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.is_entitlement", "false"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.is_group", "false"),
+					resource.TestCheckTypeSetElemNestedAttrs("identitynow_source_schema.test", "attributes.*", map[string]string{
+						"name":           "id",
+						"type":           "STRING",
+						"description":    "ID of the user Upd",
+						"is_multi":       "false",
+						"is_entitlement": "false",
+						"is_group":       "false",
+					}),
 					//resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.schema.type", "CONNECTOR_SCHEMA"),
 					//resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.schema.id", "yes"),
 					//resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.schema.name", "Id"),
 
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.name", "name"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.type", "STRING"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.description", "Name of the user"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.is_multi", "true"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.is_entitlement", "false"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.is_group", "false"),
+					resource.TestCheckTypeSetElemNestedAttrs("identitynow_source_schema.test", "attributes.*", map[string]string{
+						"name":           "name",
+						"type":           "STRING",
+						"description":    "Name of the user",
+						"is_multi":       "true",
+						"is_entitlement": "false",
+						"is_group":       "false",
+					}),
 					//resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.schema.type", "CONNECTOR_SCHEMA"),
 					//resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.schema.id", "no"),
 					//resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.schema.name", "name"),
@@ -67,23 +67,27 @@ resource "identitynow_source_schema" "test" {
 					resource.TestCheckResourceAttr("identitynow_source_schema.test", "features.0", "AUTHENTICATE"),
 					resource.TestCheckResourceAttr("identitynow_source_schema.test", "configuration", "{\"key\":\"value\"}"),
 
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.name", "id"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.type", "STRING"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.description", "ID of the user"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.is_multi", "false"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.is_entitlement", "false"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.is_group", "false"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.schema.type", "CONNECTOR_SCHEMA"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.schema.id", "yes"),
+					resource.TestCheckTypeSetElemNestedAttrs("identitynow_source_schema.test", "attributes.*", map[string]string{
+						"name":           "id",
+						"type":           "STRING",
+						"description":    "ID of the user",
+						"is_multi":       "false",
+						"is_entitlement": "false",
+						"is_group":       "false",
+						"schema.type":    "CONNECTOR_SCHEMA",
+						"schema.id":      "yes",
+					}),
 
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.name", "uid"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.type", "STRING"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.description", "UID of the user"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.is_multi", "true"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.is_entitlement", "true"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.is_group", "true"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.schema.type", "CONNECTOR_SCHEMA"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.schema.id", "no"),
+					resource.TestCheckTypeSetElemNestedAttrs("identitynow_source_schema.test", "attributes.*", map[string]string{
+						"name":           "uid",
+						"type":           "STRING",
+						"description":    "UID of the user",
+						"is_multi":       "true",
+						"is_entitlement": "true",
+						"is_group":       "true",
+						"schema.type":    "CONNECTOR_SCHEMA",
+						"schema.id":      "no",
+					}),
 				),
 			},
 			// Update and Read testing
@@ -141,25 +145,29 @@ resource "identitynow_source_schema" "test" {
 					resource.TestCheckResourceAttr("identitynow_source_schema.test", "features.1", "ENABLE"),
 					resource.TestCheckResourceAttr("identitynow_source_schema.test", "configuration", "{\"key\":\"valueUpd\"}"),
 
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.name", "idUpd"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.type", "BOOLEAN"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.description", "ID of the user Upd"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.is_multi", "false"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.is_entitlement", "false"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.is_group", "false"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.schema.type", "CONNECTOR_SCHEMA"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.schema.id", "yes"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.0.schema.name", "nameUpd"),
+					resource.TestCheckTypeSetElemNestedAttrs("identitynow_source_schema.test", "attributes.*", map[string]string{
+						"name":           "idUpd",
+						"type":           "BOOLEAN",
+						"description":    "ID of the user Upd",
+						"is_multi":       "false",
+						"is_entitlement": "false",
+						"is_group":       "false",
+						"schema.type":    "CONNECTOR_SCHEMA",
+						"schema.id":      "yes",
+						"schema.name":    "nameUpd",
+					}),
 
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.name", "uidUpd"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.type", "STRING"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.description", "UID of the user Upd"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.is_multi", "true"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.is_entitlement", "true"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.is_group", "true"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.schema.type", "CONNECTOR_SCHEMA"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.schema.id", "no"),
-					resource.TestCheckResourceAttr("identitynow_source_schema.test", "attributes.1.schema.name", "nameUpd"),
+					resource.TestCheckTypeSetElemNestedAttrs("identitynow_source_schema.test", "attributes.*", map[string]string{
+						"name":           "uidUpd",
+						"type":           "STRING",
+						"description":    "UID of the user Upd",
+						"is_multi":       "true",
+						"is_entitlement": "true",
+						"is_group":       "true",
+						"schema.type":    "CONNECTOR_SCHEMA",
+						"schema.id":      "no",
+						"schema.name":    "nameUpd",
+					}),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
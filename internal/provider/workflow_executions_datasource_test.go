@@ -0,0 +1,43 @@
+//go:build !integration
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestWorkflowExecutionsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "identitynow_workflow_executions" "test" {
+  workflow_id             = "d201c5e9-d37b-4aff-af14-66414f39d569"
+  include_failure_history = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.identitynow_workflow_executions.test", "executions.#", "2"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_executions.test", "executions.0.id", "c17bea3a-574d-453c-9e04-4365fbf5af0b"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_executions.test", "executions.0.trigger_type", "EXTERNAL"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_executions.test", "executions.0.status", "Completed"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_executions.test", "executions.0.start_time", "2024-03-05T08:30:00Z"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_executions.test", "executions.1.trigger_type", "SCHEDULED"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_executions.test", "executions.1.status", "Failed"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_executions.test", "latest_failure.execution.id", "b393f4e2-4785-4d7f-ab27-3a6b8ded4c81"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_executions.test", "latest_failure.execution.trigger_type", "SCHEDULED"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_executions.test", "latest_failure.history.#", "3"),
+					resource.TestCheckNoResourceAttr("data.identitynow_workflow_executions.test", "latest_failure.history.0.step_name"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_executions.test", "latest_failure.history.1.type", "ActivityTaskFailed"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_executions.test", "latest_failure.history.1.step_name", "Get Identities"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_executions.test", "latest_failure.history.1.attributes", `{"displayName":"Get Identities","error":"identity not found"}`),
+					resource.TestCheckNoResourceAttr("data.identitynow_workflow_executions.test", "latest_failure.history.2.attributes"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,44 @@
+//go:build !integration
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestWorkflowLibraryDataSources(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "identitynow_workflow_actions" "test" {}
+data "identitynow_workflow_triggers" "test" {}
+data "identitynow_workflow_operators" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.identitynow_workflow_actions.test", "actions.#", "2"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_actions.test", "actions.0.id", "sp:get-identities"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_actions.test", "actions.0.type", "ACTION"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_actions.test", "actions.0.version_number", "2"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_actions.test", "actions.0.form_fields.#", "2"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_actions.test", "actions.0.form_fields.0.name", "searchQuery"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_actions.test", "actions.0.form_fields.0.required", "true"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_actions.test", "actions.0.form_fields.1.type", ""),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_actions.test", "actions.1.deprecated", "true"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_triggers.test", "triggers.#", "2"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_triggers.test", "triggers.0.id", "idn:identity-created"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_triggers.test", "triggers.1.type", "EXTERNAL"),
+					resource.TestCheckNoResourceAttr("data.identitynow_workflow_triggers.test", "triggers.0.version_number"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_triggers.test", "triggers.0.form_fields.#", "0"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_operators.test", "operators.#", "1"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_operators.test", "operators.0.id", "sp:loop:iterator"),
+					resource.TestCheckResourceAttr("data.identitynow_workflow_operators.test", "operators.0.form_fields.0.type", `{"kind":"jsonpath"}`),
+				),
+			},
+		},
+	})
+}
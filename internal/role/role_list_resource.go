@@ -0,0 +1,72 @@
+package role
+
+import (
+	"context"
+	"terraform-provider-identitynow/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v2"
+	sailpoint_v3 "github.com/sailpoint-oss/golang-sdk/v2/api_v3"
+)
+
+// Implementation of IdentityNow Role listing for `terraform query` - https://developer.sailpoint.com/idn/api/v3/list-roles
+var (
+	_ list.ListResource              = &roleListResource{}
+	_ list.ListResourceWithConfigure = &roleListResource{}
+)
+
+func NewRoleListResource() list.ListResource {
+	return &roleListResource{}
+}
+
+// roleListResource shares Configure, Metadata and the state mapping with the managed resource.
+type roleListResource struct {
+	roleResource
+}
+
+func (r *roleListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = util.ListFiltersSchema(`requestable eq true`)
+}
+
+func (r *roleListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config util.ListFiltersModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	listRequest := r.apiClient.V3.RolesAPI.ListRoles(ctx)
+	if !config.Filters.IsNull() {
+		listRequest = listRequest.Filters(config.Filters.ValueString())
+	}
+	// Roles API allows at most 50 items per page
+	roles, spResp, err := sailpoint.Paginate[sailpoint_v3.Role](listRequest, 0, 50, 10000)
+	if err != nil {
+		diags.AddError(
+			"Error Listing Roles",
+			"Could not list Roles: "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = util.StreamListResults(ctx, req, roles, func(role *sailpoint_v3.Role, result *list.ListResult) {
+		result.DisplayName = role.Name
+		util.SetIdResourceIdentity(ctx, result.Identity, types.StringPointerValue(role.Id), &result.Diagnostics)
+		if !req.IncludeResource {
+			return
+		}
+		// Sets are only mapped when the role has items, so they start as typed nulls
+		model := roleModel{
+			AccessProfiles: types.SetNull(types.ObjectType{AttrTypes: util.ReferenceModelAttrTypes()}),
+			Entitlements:   types.SetNull(types.ObjectType{AttrTypes: util.ReferenceModelAttrTypes()}),
+		}
+		r.mapToTerraformModel(&model, role, &result.Diagnostics, ctx)
+		if result.Diagnostics.HasError() {
+			return
+		}
+		result.Diagnostics.Append(result.Resource.Set(ctx, &model)...)
+	})
+}
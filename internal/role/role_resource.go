@@ -3,6 +3,7 @@ package role
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"terraform-provider-identitynow/internal/patch"
 	"terraform-provider-identitynow/internal/sailpoint/custom"
 	"terraform-provider-identitynow/internal/util"
@@ -29,6 +30,7 @@ var (
 	_ resource.Resource                = &roleResource{}
 	_ resource.ResourceWithConfigure   = &roleResource{}
 	_ resource.ResourceWithImportState = &roleResource{}
+	_ resource.ResourceWithIdentity    = &roleResource{}
 )
 
 func NewRoleResource() resource.Resource {
@@ -59,6 +61,10 @@ func (r *roleResource) Metadata(_ context.Context, req resource.MetadataRequest,
 	resp.TypeName = req.ProviderTypeName + "_role"
 }
 
+func (r *roleResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = util.IdResourceIdentitySchema()
+}
+
 func (r *roleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
@@ -338,6 +344,10 @@ func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	util.SetIdResourceIdentity(ctx, resp.Identity, plan.Id, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(util.MarkCreated(ctx, resp.Private)...)
+
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
@@ -348,9 +358,12 @@ func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	util.SetIdResourceIdentity(ctx, resp.Identity, state.Id, &resp.Diagnostics)
 
-	roleResp, spResp, err := r.apiClient.V3.RolesAPI.GetRole(ctx, state.Id.ValueString()).Execute()
-	if spResp.StatusCode == 404 {
+	roleResp, spResp, err := util.ReadAfterWrite(ctx, req.Private, func() (*sailpoint_v3.Role, *http.Response, error) {
+		return r.apiClient.V3.RolesAPI.GetRole(ctx, state.Id.ValueString()).Execute()
+	})
+	if util.IsNotFound(spResp) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -408,6 +421,7 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	util.SetIdResourceIdentity(ctx, resp.Identity, plan.Id, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
@@ -899,6 +913,28 @@ func (r *roleResource) generateJsonPatch(newModel *sailpoint_v3.Role, oldModel *
 }
 
 func (r *roleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	key := util.ParseImportKey(util.GetImportId(ctx, req, &resp.Diagnostics), "role")
+	if !key.IsName() {
+		// Retrieve import ID and save to id attribute
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), key.Id)...)
+		return
+	}
+	roles, spResp, err := r.apiClient.V3.RolesAPI.ListRoles(ctx).Filters(util.FilterEquals("name", key.Name)).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Role",
+			"Could not find Role '"+key.Name+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+	for _, role := range roles {
+		if role.Name == key.Name && role.Id != nil {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), *role.Id)...)
+			return
+		}
+	}
+	resp.Diagnostics.AddError(
+		"Error Importing Role",
+		"Could not find Role '"+key.Name+"'",
+	)
 }
@@ -0,0 +1,130 @@
+package custom
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// The connector detail, with the configuration form of sources, is not part of the SDK. It is read with the custom client.
+
+// ConnectorDetail is a connector with its source configuration form - https://developer.sailpoint.com/docs/api/beta/get-connector
+type ConnectorDetail struct {
+	Name          string `json:"name"`
+	SourceConfig  string `json:"sourceConfig"`
+	DirectConnect bool   `json:"directConnect"`
+	FileUpload    bool   `json:"fileUpload"`
+}
+
+// ConnectorAttribute is a connector attribute of sources, as defined by the configuration form of the connector.
+// Conditional attributes are only shown when another attribute has a value (`parentKey` and `parentValue`).
+type ConnectorAttribute struct {
+	Key         string
+	Label       string
+	Type        string
+	Required    bool
+	Conditional bool
+}
+
+// GetConnector reads a connector by its script name.
+func (c *APIClient) GetConnector(ctx context.Context, scriptName string) (*ConnectorDetail, *http.Response, error) {
+	headers := map[string]string{
+		"Accept": "application/json",
+	}
+	response, err := c.doCall(ctx, http.MethodGet, "/beta/connectors/"+url.PathEscape(scriptName), nil, headers)
+	if err != nil {
+		return nil, response, err
+	}
+	var connector ConnectorDetail
+	if err = c.unmarshalBody(response, &connector); err != nil {
+		return nil, response, err
+	}
+	return &connector, response, nil
+}
+
+// ConnectorAttributes returns the attribute specification of a connector. Specifications are read once per provider.
+func (c *APIClient) ConnectorAttributes(ctx context.Context, scriptName string) ([]ConnectorAttribute, *http.Response, error) {
+	c.connectorAttributesMutex.Lock()
+	defer c.connectorAttributesMutex.Unlock()
+	if attributes, ok := c.connectorAttributes[scriptName]; ok {
+		return attributes, nil, nil
+	}
+	connector, response, err := c.GetConnector(ctx, scriptName)
+	if err != nil {
+		return nil, response, err
+	}
+	attributes, err := ParseConnectorAttributes(connector.SourceConfig)
+	if err != nil {
+		return nil, response, err
+	}
+	if c.connectorAttributes == nil {
+		c.connectorAttributes = map[string][]ConnectorAttribute{}
+	}
+	c.connectorAttributes[scriptName] = attributes
+	return attributes, response, nil
+}
+
+// ParseConnectorAttributes returns the fields of a source configuration form. Fields are the form items with a `key`,
+// items nested in a field (e.g. the entries of a card list) are not attributes of the source. Fields with a parent
+// condition, or inside an item with one, are conditional.
+func ParseConnectorAttributes(sourceConfig string) ([]ConnectorAttribute, error) {
+	if sourceConfig == "" {
+		return nil, nil
+	}
+	var form interface{}
+	if err := json.Unmarshal([]byte(sourceConfig), &form); err != nil {
+		return nil, err
+	}
+	var attributes []ConnectorAttribute
+	seen := map[string]bool{}
+	var walk func(value interface{}, conditional bool)
+	walk = func(value interface{}, conditional bool) {
+		switch item := value.(type) {
+		case []interface{}:
+			for _, element := range item {
+				walk(element, conditional)
+			}
+		case map[string]interface{}:
+			conditional = conditional || hasParentCondition(item)
+			if key, ok := item["key"].(string); ok && key != "" {
+				if !seen[key] {
+					seen[key] = true
+					attribute := ConnectorAttribute{Key: key, Type: "text", Conditional: conditional}
+					if label, ok := item["label"].(string); ok {
+						attribute.Label = label
+					}
+					if fieldType, ok := item["type"].(string); ok && fieldType != "" {
+						attribute.Type = fieldType
+					}
+					if required, ok := item["required"].(bool); ok {
+						attribute.Required = required
+					}
+					attributes = append(attributes, attribute)
+				}
+				return
+			}
+			// Sorted, so the attributes keep their order between reads
+			names := make([]string, 0, len(item))
+			for name := range item {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				walk(item[name], conditional)
+			}
+		}
+	}
+	walk(form, false)
+	return attributes, nil
+}
+
+func hasParentCondition(item map[string]interface{}) bool {
+	for _, name := range []string{"parentKey", "parentValue"} {
+		if value, ok := item[name]; ok && value != nil && value != "" {
+			return true
+		}
+	}
+	return false
+}
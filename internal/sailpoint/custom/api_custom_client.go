@@ -12,6 +12,8 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 )
 
 func NewAPIClient(spApiClient *sailpoint.APIClient, config *sailpoint.Configuration) *APIClient {
@@ -21,10 +23,32 @@ func NewAPIClient(spApiClient *sailpoint.APIClient, config *sailpoint.Configurat
 	}
 }
 
+// NewAPIClientForTenant creates the client of the provider and the export command for the tenant API at host,
+// authenticating with the client credentials.
+func NewAPIClientForTenant(host, clientId, clientSecret string) *APIClient {
+	configuration := sailpoint.NewConfiguration(sailpoint.ClientConfiguration{
+		ClientId:     clientId,
+		ClientSecret: clientSecret,
+		BaseURL:      host,
+		TokenURL:     host + "/oauth/token",
+	})
+	apiClient := sailpoint.NewAPIClient(configuration)
+	configuration.HTTPClient.RetryMax = 5
+	return NewAPIClient(apiClient, configuration)
+}
+
 type APIClient struct {
 	ApiClient *sailpoint.APIClient
 	config    *sailpoint.Configuration
-	token     *oauth2.Token
+
+	tokenMutex sync.Mutex
+	token      *oauth2.Token
+
+	workflowStepCatalogMutex sync.Mutex
+	workflowStepCatalog      map[string]WorkflowLibraryItem
+
+	connectorAttributesMutex sync.Mutex
+	connectorAttributes      map[string][]ConnectorAttribute
 }
 
 func (c *APIClient) doCall(ctx context.Context, method, uri string, body *string, headers map[string]string) (*http.Response, error) {
@@ -44,7 +68,7 @@ func (c *APIClient) doCall(ctx context.Context, method, uri string, body *string
 	for k, v := range headers {
 		request.Header.Add(k, v)
 	}
-	token, err := c.getAuthToken(ctx)
+	token, err := c.getAuthToken(ctx, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -68,8 +92,22 @@ func (c *APIClient) unmarshalBody(response *http.Response, v interface{}) error
 	}
 	return json.Unmarshal(bodyBytes, v)
 }
-func (c *APIClient) getAuthToken(ctx context.Context) (token *oauth2.Token, err error) {
-	if c.token != nil && c.token.Valid() {
+
+// AccessToken returns the bearer token the client calls the API with, a new token is requested when it expires within
+// minLifetime.
+func (c *APIClient) AccessToken(ctx context.Context, minLifetime time.Duration) (*oauth2.Token, error) {
+	return c.getAuthToken(ctx, minLifetime)
+}
+
+// BaseURL returns the URL of the tenant API.
+func (c *APIClient) BaseURL() string {
+	return c.config.ClientConfiguration.BaseURL
+}
+
+func (c *APIClient) getAuthToken(ctx context.Context, minLifetime time.Duration) (token *oauth2.Token, err error) {
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+	if c.token != nil && c.token.Valid() && (c.token.Expiry.IsZero() || time.Until(c.token.Expiry) > minLifetime) {
 		return c.token, nil
 	}
 	config := c.ApiClient.Beta.GetConfig()
@@ -112,5 +150,12 @@ func (c *APIClient) getAccessToken(ctx context.Context, clientId string, clientS
 	if err != nil {
 		return nil, err
 	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("error requesting access token from %s: %v", tokenURL, res.Status)
+	}
+	// The token endpoint returns expires_in only, without an expiry the token would be cached forever
+	if token.Expiry.IsZero() && token.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
 	return &token, nil
 }
@@ -0,0 +1,50 @@
+package custom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func TestGetAuthToken(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "clientId", r.PostForm.Get("client_id"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "bearer", "expires_in": 3600}`, requests)
+	}))
+	defer server.Close()
+
+	client := NewAPIClientForTenant(server.URL, "clientId", "clientSecret")
+	ctx := context.Background()
+
+	token, err := client.getAuthToken(ctx, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), token.Expiry, time.Minute)
+
+	// A token with more than minLifetime left is reused
+	token, err = client.getAuthToken(ctx, 10*time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token.AccessToken)
+	assert.Equal(t, 1, requests)
+
+	// A token with less than minLifetime left is refreshed
+	client.token = &oauth2.Token{AccessToken: "expiring", TokenType: "bearer", Expiry: time.Now().Add(5 * time.Minute)}
+	token, err = client.getAuthToken(ctx, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "expiring", token.AccessToken)
+	token, err = client.getAuthToken(ctx, 10*time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", token.AccessToken)
+	assert.Equal(t, 2, requests)
+	assert.Same(t, token, client.token)
+}
@@ -2,98 +2,109 @@ package custom
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 	"net/http"
 	"net/url"
 )
 
-const defaultCronExpression = "0 0 0 * * ?"
+// Source schedules are not part of the SDK version used by the provider, so they are managed with the custom client -
+// https://developer.sailpoint.com/docs/api/v2024/source-schedules
 
-// todo: getEntitlementAggregationSchedules
+const (
+	SourceScheduleTypeAccountAggregation = "ACCOUNT_AGGREGATION"
+	SourceScheduleTypeGroupAggregation   = "GROUP_AGGREGATION"
+)
 
-func (c *APIClient) ReadSourceAccountAggregationSchedule(ctx context.Context, sourceCloudId string) (*SourceAggregationSchedule, *http.Response, error) {
-	uri := fmt.Sprintf("/cc/api/source/getAggregationSchedules/%s", sourceCloudId)
+// SourceScheduleTypes lists all schedule types supported by the source schedules API.
+var SourceScheduleTypes = []string{SourceScheduleTypeAccountAggregation, SourceScheduleTypeGroupAggregation}
 
-	return c.getScheduledAggregation(ctx, uri)
+type SourceSchedule struct {
+	Type           string `json:"type"`
+	CronExpression string `json:"cronExpression"`
+	Created        string `json:"created,omitempty"`
+	Modified       string `json:"modified,omitempty"`
 }
 
-func (c *APIClient) ModifySourceAccountAggregationSchedule(ctx context.Context, sourceCloudId, cronExpression string) (*SourceAggregationSchedule, *http.Response, error) {
-	uri := fmt.Sprintf("/cc/api/source/scheduleAggregation/%s", sourceCloudId)
-	data := url.Values{}
-	data.Set("enable", "true")
-	data.Set("cronExp", cronExpression)
-
-	return c.invokeScheduledAggregation(ctx, uri, data)
+// ListSourceSchedules lists schedules of all types of the source - https://developer.sailpoint.com/docs/api/v2024/get-source-schedules
+func (c *APIClient) ListSourceSchedules(ctx context.Context, sourceId string) ([]SourceSchedule, *http.Response, error) {
+	response, err := c.doCall(ctx, http.MethodGet, sourceSchedulesUri(sourceId), nil, sourceScheduleHeaders("application/json"))
+	if err != nil {
+		return nil, response, err
+	}
+	var schedules []SourceSchedule
+	if err = c.unmarshalBody(response, &schedules); err != nil {
+		return nil, response, err
+	}
+	return schedules, response, nil
 }
 
-func (c *APIClient) DeleteSourceAccountAggregationSchedule(ctx context.Context, sourceCloudId string) (*http.Response, error) {
-	uri := fmt.Sprintf("/cc/api/source/scheduleAggregation/%s", sourceCloudId)
-	data := url.Values{}
-	data.Set("enable", "false")
-	data.Set("cronExp", defaultCronExpression)
-	_, response, err := c.invokeScheduledAggregation(ctx, uri, data)
-	return response, err
+// GetSourceSchedule reads the schedule of the given type - https://developer.sailpoint.com/docs/api/v2024/get-source-schedule
+func (c *APIClient) GetSourceSchedule(ctx context.Context, sourceId, scheduleType string) (*SourceSchedule, *http.Response, error) {
+	response, err := c.doCall(ctx, http.MethodGet, sourceScheduleUri(sourceId, scheduleType), nil, sourceScheduleHeaders("application/json"))
+	if err != nil {
+		return nil, response, err
+	}
+	var schedule SourceSchedule
+	if err = c.unmarshalBody(response, &schedule); err != nil {
+		return nil, response, err
+	}
+	return &schedule, response, nil
 }
 
-func (c *APIClient) ReadSourceEntitlementAggregationSchedule(ctx context.Context, sourceCloudId string) (*SourceAggregationSchedule, *http.Response, error) {
-	uri := fmt.Sprintf("/cc/api/source/getEntitlementAggregationSchedules/%s", sourceCloudId)
-
-	return c.getScheduledAggregation(ctx, uri)
+// CreateSourceSchedule creates a schedule of a type the source has no schedule for - https://developer.sailpoint.com/docs/api/v2024/create-source-schedule
+func (c *APIClient) CreateSourceSchedule(ctx context.Context, sourceId string, schedule SourceSchedule) (*SourceSchedule, *http.Response, error) {
+	body, err := json.Marshal(SourceSchedule{Type: schedule.Type, CronExpression: schedule.CronExpression})
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.writeSourceSchedule(ctx, http.MethodPost, sourceSchedulesUri(sourceId), string(body), "application/json")
 }
 
-func (c *APIClient) ModifySourceEntitlementAggregationSchedule(ctx context.Context, sourceCloudId, cronExpression string) (*SourceAggregationSchedule, *http.Response, error) {
-	uri := fmt.Sprintf("/cc/api/source/scheduleEntitlementAggregation/%s", sourceCloudId)
-	data := url.Values{}
-	data.Set("enable", "true")
-	data.Set("cronExp", cronExpression)
-
-	return c.invokeScheduledAggregation(ctx, uri, data)
+// UpdateSourceScheduleCronExpression replaces the cron expression of the schedule - https://developer.sailpoint.com/docs/api/v2024/update-source-schedule
+func (c *APIClient) UpdateSourceScheduleCronExpression(ctx context.Context, sourceId, scheduleType, cronExpression string) (*SourceSchedule, *http.Response, error) {
+	body, err := json.Marshal([]map[string]interface{}{
+		{"op": "replace", "path": "/cronExpression", "value": cronExpression},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.writeSourceSchedule(ctx, http.MethodPatch, sourceScheduleUri(sourceId, scheduleType), string(body), "application/json-patch+json")
 }
 
-func (c *APIClient) DeleteSourceEntitlementAggregationSchedule(ctx context.Context, sourceCloudId string) (*http.Response, error) {
-	uri := fmt.Sprintf("/cc/api/source/scheduleEntitlementAggregation/%s", sourceCloudId)
-	data := url.Values{}
-	data.Set("enable", "false")
-	data.Set("cronExp", defaultCronExpression)
-	_, response, err := c.invokeScheduledAggregation(ctx, uri, data)
-	return response, err
+// DeleteSourceSchedule deletes the schedule of the given type - https://developer.sailpoint.com/docs/api/v2024/delete-source-schedule
+func (c *APIClient) DeleteSourceSchedule(ctx context.Context, sourceId, scheduleType string) (*http.Response, error) {
+	return c.doCall(ctx, http.MethodDelete, sourceScheduleUri(sourceId, scheduleType), nil, sourceScheduleHeaders(""))
 }
 
-func (c *APIClient) invokeScheduledAggregation(ctx context.Context, uri string, data url.Values) (*SourceAggregationSchedule, *http.Response, error) {
-	headers := map[string]string{
-		"Accept":       "application/json",
-		"Content-Type": "application/x-www-form-urlencoded; charset=utf-8",
-	}
-	body := data.Encode()
-	response, err := c.doCall(ctx, http.MethodPost, uri, &body, headers)
+func (c *APIClient) writeSourceSchedule(ctx context.Context, method, uri, body, contentType string) (*SourceSchedule, *http.Response, error) {
+	headers := sourceScheduleHeaders("application/json")
+	headers["Content-Type"] = contentType
+	response, err := c.doCall(ctx, method, uri, &body, headers)
 	if err != nil {
-		return nil, nil, err
+		return nil, response, err
 	}
-	var config SourceAggregationSchedule
-	if err = c.unmarshalBody(response, &config); err != nil {
+	var schedule SourceSchedule
+	if err = c.unmarshalBody(response, &schedule); err != nil {
 		return nil, response, err
 	}
-	return &config, response, nil
+	return &schedule, response, nil
+}
+
+func sourceSchedulesUri(sourceId string) string {
+	return "/v2024/sources/" + url.PathEscape(sourceId) + "/schedules"
 }
 
-func (c *APIClient) getScheduledAggregation(ctx context.Context, uri string) (*SourceAggregationSchedule, *http.Response, error) {
+func sourceScheduleUri(sourceId, scheduleType string) string {
+	return sourceSchedulesUri(sourceId) + "/" + url.PathEscape(scheduleType)
+}
+
+// sourceScheduleHeaders returns headers of the source schedules API, which is experimental in v2024.
+func sourceScheduleHeaders(accept string) map[string]string {
 	headers := map[string]string{
-		"Accept": "application/json",
-	}
-	response, err := c.doCall(ctx, http.MethodGet, uri, nil, headers)
-	if err != nil {
-		return nil, nil, err
+		"X-SailPoint-Experimental": "true",
 	}
-	var configs []SourceAggregationSchedule
-	if err = c.unmarshalBody(response, &configs); err != nil {
-		return nil, response, err
-	}
-	if len(configs) == 0 {
-		return nil, response, nil
+	if accept != "" {
+		headers["Accept"] = accept
 	}
-	return &configs[0], response, nil
-}
-
-type SourceAggregationSchedule struct {
-	CronExpressions []string `json:"cronExpressions"`
+	return headers
 }
@@ -0,0 +1,86 @@
+package custom
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// Source correlation configs are not part of the SDK version used by the provider, so they are managed with the custom
+// client - https://developer.sailpoint.com/docs/api/v2024/get-correlation-config
+
+const (
+	CorrelationMatchModeAnywhere = "ANYWHERE"
+	CorrelationMatchModeStart    = "START"
+	CorrelationMatchModeEnd      = "END"
+	CorrelationOperationEquals   = "EQ"
+)
+
+// CorrelationMatchModes lists the match modes of correlation attribute assignments.
+var CorrelationMatchModes = []string{CorrelationMatchModeAnywhere, CorrelationMatchModeStart, CorrelationMatchModeEnd}
+
+type CorrelationConfig struct {
+	Id                   string                        `json:"id,omitempty"`
+	Name                 string                        `json:"name,omitempty"`
+	AttributeAssignments []CorrelationAttributeMapping `json:"attributeAssignments"`
+}
+
+// CorrelationAttributeMapping correlates accounts whose attribute `Value` matches the identity attribute `Property`.
+type CorrelationAttributeMapping struct {
+	Property     string `json:"property"`
+	Value        string `json:"value"`
+	Operation    string `json:"operation,omitempty"`
+	Complex      bool   `json:"complex"`
+	IgnoreCase   bool   `json:"ignoreCase"`
+	MatchMode    string `json:"matchMode,omitempty"`
+	FilterString string `json:"filterString,omitempty"`
+}
+
+// GetSourceCorrelationConfig reads the account correlation config of the source - https://developer.sailpoint.com/docs/api/v2024/get-correlation-config
+func (c *APIClient) GetSourceCorrelationConfig(ctx context.Context, sourceId string) (*CorrelationConfig, *http.Response, error) {
+	response, err := c.doCall(ctx, http.MethodGet, sourceCorrelationConfigUri(sourceId), nil, correlationConfigHeaders())
+	if err != nil {
+		return nil, response, err
+	}
+	var config CorrelationConfig
+	if err = c.unmarshalBody(response, &config); err != nil {
+		return nil, response, err
+	}
+	return &config, response, nil
+}
+
+// PutSourceCorrelationConfig replaces the account correlation config of the source - https://developer.sailpoint.com/docs/api/v2024/put-correlation-config
+func (c *APIClient) PutSourceCorrelationConfig(ctx context.Context, sourceId string, config CorrelationConfig) (*CorrelationConfig, *http.Response, error) {
+	if config.AttributeAssignments == nil {
+		config.AttributeAssignments = []CorrelationAttributeMapping{}
+	}
+	body, err := json.Marshal(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	payload := string(body)
+	headers := correlationConfigHeaders()
+	headers["Content-Type"] = "application/json"
+	response, err := c.doCall(ctx, http.MethodPut, sourceCorrelationConfigUri(sourceId), &payload, headers)
+	if err != nil {
+		return nil, response, err
+	}
+	var updated CorrelationConfig
+	if err = c.unmarshalBody(response, &updated); err != nil {
+		return nil, response, err
+	}
+	return &updated, response, nil
+}
+
+func sourceCorrelationConfigUri(sourceId string) string {
+	return "/v2024/sources/" + url.PathEscape(sourceId) + "/correlation-config"
+}
+
+// correlationConfigHeaders returns headers of the correlation config API, which is experimental in v2024.
+func correlationConfigHeaders() map[string]string {
+	return map[string]string{
+		"Accept":                   "application/json",
+		"X-SailPoint-Experimental": "true",
+	}
+}
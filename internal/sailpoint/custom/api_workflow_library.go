@@ -0,0 +1,111 @@
+package custom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Workflow library models of the SDK declare `type` attributes as objects, so they fail to decode the strings returned by
+// IdentityNow. The library is read with the custom client instead.
+
+const workflowLibraryPageSize = 250
+
+// ListWorkflowLibraryActions lists all actions available for workflow steps - https://developer.sailpoint.com/docs/api/beta/list-workflow-library-actions
+func (c *APIClient) ListWorkflowLibraryActions(ctx context.Context) ([]WorkflowLibraryItem, *http.Response, error) {
+	return c.listWorkflowLibrary(ctx, "/beta/workflow-library/actions", true)
+}
+
+// ListWorkflowLibraryTriggers lists all triggers which can start a workflow - https://developer.sailpoint.com/docs/api/beta/list-workflow-library-triggers
+func (c *APIClient) ListWorkflowLibraryTriggers(ctx context.Context) ([]WorkflowLibraryItem, *http.Response, error) {
+	return c.listWorkflowLibrary(ctx, "/beta/workflow-library/triggers", true)
+}
+
+// ListWorkflowLibraryOperators lists all operators (choice, loop, wait, ...) of workflow steps - https://developer.sailpoint.com/docs/api/beta/list-workflow-library-operators
+func (c *APIClient) ListWorkflowLibraryOperators(ctx context.Context) ([]WorkflowLibraryItem, *http.Response, error) {
+	return c.listWorkflowLibrary(ctx, "/beta/workflow-library/operators", false)
+}
+
+// WorkflowStepCatalog returns library actions and operators keyed by their id. The catalog is read once per provider.
+func (c *APIClient) WorkflowStepCatalog(ctx context.Context) (map[string]WorkflowLibraryItem, error) {
+	c.workflowStepCatalogMutex.Lock()
+	defer c.workflowStepCatalogMutex.Unlock()
+	if c.workflowStepCatalog != nil {
+		return c.workflowStepCatalog, nil
+	}
+	actions, _, err := c.ListWorkflowLibraryActions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	operators, _, err := c.ListWorkflowLibraryOperators(ctx)
+	if err != nil {
+		return nil, err
+	}
+	catalog := make(map[string]WorkflowLibraryItem, len(actions)+len(operators))
+	for _, item := range append(actions, operators...) {
+		catalog[item.Id] = item
+	}
+	c.workflowStepCatalog = catalog
+	return catalog, nil
+}
+
+func (c *APIClient) listWorkflowLibrary(ctx context.Context, uri string, paginated bool) ([]WorkflowLibraryItem, *http.Response, error) {
+	headers := map[string]string{
+		"Accept": "application/json",
+	}
+	var items []WorkflowLibraryItem
+	for offset := 0; ; offset += workflowLibraryPageSize {
+		pageUri := uri
+		if paginated {
+			pageUri = fmt.Sprintf("%s?limit=%d&offset=%d", uri, workflowLibraryPageSize, offset)
+		}
+		response, err := c.doCall(ctx, http.MethodGet, pageUri, nil, headers)
+		if err != nil {
+			return nil, response, err
+		}
+		var page []WorkflowLibraryItem
+		if err = c.unmarshalBody(response, &page); err != nil {
+			return nil, response, err
+		}
+		items = append(items, page...)
+		if !paginated || len(page) < workflowLibraryPageSize {
+			return items, response, nil
+		}
+	}
+}
+
+type WorkflowLibraryItem struct {
+	Id            string                     `json:"id"`
+	Name          string                     `json:"name"`
+	Type          LibraryType                `json:"type"`
+	Description   string                     `json:"description"`
+	VersionNumber *int64                     `json:"versionNumber"`
+	Deprecated    bool                       `json:"deprecated"`
+	FormFields    []WorkflowLibraryFormField `json:"formFields"`
+}
+
+type WorkflowLibraryFormField struct {
+	Name     string      `json:"name"`
+	Label    string      `json:"label"`
+	HelpText string      `json:"helpText"`
+	Required bool        `json:"required"`
+	Type     LibraryType `json:"type"`
+}
+
+// LibraryType is a type of library item or form field. It is usually a string, other JSON values are kept as JSON text.
+type LibraryType string
+
+func (t *LibraryType) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*t = LibraryType(value)
+		return nil
+	}
+	if string(data) == "null" {
+		*t = ""
+		return nil
+	}
+	*t = LibraryType(data)
+	return nil
+}
@@ -0,0 +1,188 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"terraform-provider-identitynow/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Form field types of connectors and the JSON values they accept, fields of other types are not type checked.
+var (
+	connectorNumberTypes = []string{"number", "int", "integer", "long"}
+	connectorBoolTypes   = []string{"checkbox", "toggle", "boolean"}
+	connectorListTypes   = []string{"list", "multiselect", "cardList"}
+	connectorStringTypes = []string{"text", "textarea", "secret", "secure", "password", "url", "email"}
+)
+
+// connectorAttributeProblems lists the problems of connector attributes compared to the specification of the connector.
+type connectorAttributeProblems struct {
+	Errors   []string
+	Warnings []string
+}
+
+// checkConnectorAttributes validates `connector_attributes` against the attribute specification of the connector, unless
+// `validate_connector_attributes` is false. Keys of the credentials count as set for required attributes, their values
+// are not checked. When the specification can not be read the attributes are not checked.
+func (r *sourceResource) checkConnectorAttributes(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var validate types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("validate_connector_attributes"), &validate)...)
+	if resp.Diagnostics.HasError() || (!validate.IsNull() && !validate.ValueBool()) {
+		return
+	}
+	var connector types.String
+	var attributes jsontypes.Normalized
+	var credentials, credentialsWo jsontypes.Exact
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("connector"), &connector)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("connector_attributes"), &attributes)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("connector_attributes_credentials"), &credentials)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("connector_attributes_credentials_wo"), &credentialsWo)...)
+	if resp.Diagnostics.HasError() || r.customClient == nil {
+		return
+	}
+	if connector.IsNull() || connector.IsUnknown() || attributes.IsNull() || attributes.IsUnknown() {
+		return
+	}
+
+	var diagnostics diag.Diagnostics
+	values := util.UnmarshalJsonTypeNormalized(attributes, &diagnostics)
+	var credentialKeys []string
+	checkRequired := true
+	for _, value := range []jsontypes.Exact{credentials, credentialsWo} {
+		if value.IsUnknown() {
+			checkRequired = false
+			continue
+		}
+		for key := range util.UnmarshalJsonType(value, &diagnostics) {
+			credentialKeys = append(credentialKeys, key)
+		}
+	}
+	if diagnostics.HasError() {
+		// Reported when the attributes are sent
+		return
+	}
+
+	spec, _, err := r.customClient.ConnectorAttributes(ctx, connector.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("connector_attributes"),
+			"Connector Attributes Not Checked",
+			"Could not read the attributes of connector '"+connector.ValueString()+"', connector_attributes are not checked: "+err.Error(),
+		)
+		return
+	}
+	if len(spec) == 0 {
+		return
+	}
+	problems := validateConnectorAttributes(values, credentialKeys, checkRequired, spec)
+	for _, problem := range problems.Errors {
+		resp.Diagnostics.AddAttributeError(path.Root("connector_attributes"), "Invalid Connector Attribute", problem)
+	}
+	for _, problem := range problems.Warnings {
+		resp.Diagnostics.AddAttributeWarning(path.Root("connector_attributes"), "Unknown Connector Attribute", problem)
+	}
+}
+
+// validateConnectorAttributes reports attributes of a wrong type and missing required attributes as errors. Attributes
+// unknown to the connector are only warnings, sources keep attributes maintained by IdentityNow among them. Attributes
+// shown depending on another attribute are not checked, the form may hold them in several variants.
+func validateConnectorAttributes(values map[string]interface{}, credentialKeys []string, checkRequired bool, spec []custom.ConnectorAttribute) connectorAttributeProblems {
+	var problems connectorAttributeProblems
+	specByKey := make(map[string]custom.ConnectorAttribute, len(spec))
+	for _, attribute := range spec {
+		specByKey[attribute.Key] = attribute
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		attribute, ok := specByKey[key]
+		if !ok {
+			if !isNestedConnectorAttribute(key, spec) {
+				problems.Warnings = append(problems.Warnings, fmt.Sprintf("Key '%s' is not an attribute of the connector", key))
+			}
+			continue
+		}
+		if attribute.Conditional {
+			continue
+		}
+		if expected, ok := connectorAttributeTypeMatches(attribute.Type, values[key]); !ok {
+			problems.Errors = append(problems.Errors, fmt.Sprintf("Key '%s' must be %s for field type '%s', got: %v", key, expected, attribute.Type, values[key]))
+		}
+	}
+
+	if checkRequired {
+		for _, attribute := range spec {
+			if !attribute.Required || attribute.Conditional || strings.Contains(attribute.Key, ".") {
+				continue
+			}
+			if _, ok := values[attribute.Key]; ok || slices.Contains(credentialKeys, attribute.Key) {
+				continue
+			}
+			label := ""
+			if attribute.Label != "" {
+				label = " (" + attribute.Label + ")"
+			}
+			problems.Errors = append(problems.Errors, fmt.Sprintf("Key '%s'%s is required by the connector", attribute.Key, label))
+		}
+	}
+	return problems
+}
+
+// isNestedConnectorAttribute returns whether the key holds nested attributes of the connector, like `key.name`.
+func isNestedConnectorAttribute(key string, spec []custom.ConnectorAttribute) bool {
+	for _, attribute := range spec {
+		if strings.HasPrefix(attribute.Key, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// connectorAttributeTypeMatches returns whether the value fits the field type, and otherwise the expected JSON type.
+// Numbers and booleans are accepted as strings as well, IdentityNow stores many of them as strings.
+func connectorAttributeTypeMatches(fieldType string, value interface{}) (string, bool) {
+	if value == nil {
+		return "", true
+	}
+	switch {
+	case slices.Contains(connectorNumberTypes, fieldType):
+		switch typed := value.(type) {
+		case float64:
+			return "", true
+		case string:
+			_, err := strconv.ParseFloat(typed, 64)
+			return "a number", err == nil
+		}
+		return "a number", false
+	case slices.Contains(connectorBoolTypes, fieldType):
+		switch typed := value.(type) {
+		case bool:
+			return "", true
+		case string:
+			_, err := strconv.ParseBool(typed)
+			return "a boolean", err == nil
+		}
+		return "a boolean", false
+	case slices.Contains(connectorListTypes, fieldType):
+		_, ok := value.([]interface{})
+		return "a list", ok
+	case slices.Contains(connectorStringTypes, fieldType):
+		_, ok := value.(string)
+		return "a string", ok
+	}
+	return "", true
+}
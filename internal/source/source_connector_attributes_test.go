@@ -0,0 +1,100 @@
+package source
+
+import (
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConnectorAttributes(t *testing.T) {
+	attributes, err := custom.ParseConnectorAttributes(`[{"sections": [{"items": [
+		{"key": "host", "label": "Host", "type": "text", "required": true},
+		{"key": "port", "type": "number"},
+		{"key": "groups", "type": "cardList", "fields": [{"key": "name"}]},
+		{"key": "host"},
+		{"label": "No key"},
+		{"key": "authType", "type": "text", "required": true, "parentKey": "useSSL", "parentValue": true}
+	]}, {"parentKey": "useKerberos", "items": [{"key": "realm", "required": true}]}]}]`)
+	assert.NoError(t, err)
+	assert.Equal(t, []custom.ConnectorAttribute{
+		{Key: "host", Label: "Host", Type: "text", Required: true},
+		{Key: "port", Type: "number"},
+		{Key: "groups", Type: "cardList"},
+		{Key: "authType", Type: "text", Required: true, Conditional: true},
+		{Key: "realm", Type: "text", Required: true, Conditional: true},
+	}, attributes)
+
+	attributes, err = custom.ParseConnectorAttributes("")
+	assert.NoError(t, err)
+	assert.Empty(t, attributes)
+
+	_, err = custom.ParseConnectorAttributes("{")
+	assert.Error(t, err)
+}
+
+func TestValidateConnectorAttributes(t *testing.T) {
+	spec := []custom.ConnectorAttribute{
+		{Key: "host", Label: "Host", Type: "text", Required: true},
+		{Key: "port", Type: "number", Required: true},
+		{Key: "password", Type: "secret", Required: true},
+		{Key: "useSSL", Type: "checkbox"},
+		{Key: "group.name", Type: "text", Required: true},
+		{Key: "realm", Type: "number", Required: true, Conditional: true},
+	}
+
+	problems := validateConnectorAttributes(map[string]interface{}{
+		"host":   "localhost",
+		"port":   "636",
+		"useSSL": true,
+		"group":  map[string]interface{}{"name": "groups"},
+		"realm":  "EXAMPLE.COM",
+	}, []string{"password"}, true, spec)
+	assert.Empty(t, problems.Errors)
+	assert.Empty(t, problems.Warnings)
+
+	problems = validateConnectorAttributes(map[string]interface{}{
+		"port":    "ldaps",
+		"useSSL":  "yes",
+		"unknown": "value",
+	}, nil, true, spec)
+	assert.Equal(t, []string{
+		"Key 'port' must be a number for field type 'number', got: ldaps",
+		"Key 'useSSL' must be a boolean for field type 'checkbox', got: yes",
+		"Key 'host' (Host) is required by the connector",
+		"Key 'password' is required by the connector",
+	}, problems.Errors)
+	assert.Equal(t, []string{"Key 'unknown' is not an attribute of the connector"}, problems.Warnings)
+
+	problems = validateConnectorAttributes(map[string]interface{}{"port": float64(636)}, nil, false, spec)
+	assert.Empty(t, problems.Errors)
+}
+
+func TestConnectorAttributeTypeMatches(t *testing.T) {
+	tests := []struct {
+		fieldType string
+		value     interface{}
+		expected  string
+		ok        bool
+	}{
+		{"number", float64(1), "", true},
+		{"long", "10", "a number", true},
+		{"int", true, "a number", false},
+		{"toggle", false, "", true},
+		{"checkbox", "true", "a boolean", true},
+		{"boolean", float64(1), "a boolean", false},
+		{"list", []interface{}{"a"}, "a list", true},
+		{"multiselect", "a", "a list", false},
+		{"text", "a", "a string", true},
+		{"password", float64(1), "a string", false},
+		{"custom", float64(1), "", true},
+		{"number", nil, "", true},
+	}
+	for _, test := range tests {
+		expected, ok := connectorAttributeTypeMatches(test.fieldType, test.value)
+		assert.Equal(t, test.ok, ok, test.fieldType)
+		if !ok {
+			assert.Equal(t, test.expected, expected, test.fieldType)
+		}
+	}
+}
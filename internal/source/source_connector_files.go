@@ -0,0 +1,196 @@
+package source
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"terraform-provider-identitynow/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sailpoint_v3 "github.com/sailpoint-oss/golang-sdk/v2/api_v3"
+)
+
+const connectorFilesAttribute = "connector_files"
+
+// planConnectorFileHashes computes the hashes of the connector files, so a file rebuilt under the same name shows up in the plan.
+func (r *sourceResource) planConnectorFileHashes(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var files types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("connector_files"), &files)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	hashes, err := connectorFileHashes(files)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("connector_files"), "Invalid Connector File", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("connector_file_hashes"), hashes)...)
+}
+
+// syncConnectorFiles uploads the connector files that are new or whose content changed since prior, and removes the
+// files dropped from `connector_files` from the Source. It returns the Source after the last change, or source when
+// nothing changed.
+func (r *sourceResource) syncConnectorFiles(ctx context.Context, model *sourceModel, prior *sourceModel, source *sailpoint_v3.Source, diagnostics *diag.Diagnostics) *sailpoint_v3.Source {
+	hashes, err := connectorFileHashes(model.ConnectorFiles)
+	if err != nil {
+		diagnostics.AddAttributeError(path.Root("connector_files"), "Invalid Connector File", err.Error())
+		return source
+	}
+	priorHashes := map[string]string{}
+	var priorFiles []string
+	if prior != nil {
+		diagnostics.Append(prior.ConnectorFileHashes.ElementsAs(ctx, &priorHashes, false)...)
+		priorFiles = getStringArray(prior.ConnectorFiles)
+	}
+	currentHashes := map[string]string{}
+	diagnostics.Append(hashes.ElementsAs(ctx, &currentHashes, false)...)
+	if diagnostics.HasError() {
+		return source
+	}
+
+	uploads, removed := connectorFileChanges(getStringArray(model.ConnectorFiles), priorFiles, currentHashes, priorHashes)
+	for _, file := range uploads {
+		uploaded := r.uploadConnectorFiles(ctx, *source.Id, file, diagnostics)
+		if diagnostics.HasError() {
+			return source
+		}
+		if uploaded != nil {
+			source = uploaded
+		}
+	}
+	if len(removed) > 0 {
+		source = r.removeConnectorFiles(ctx, source, removed, diagnostics)
+		if diagnostics.HasError() {
+			return source
+		}
+	}
+
+	model.ConnectorFileHashes = hashes
+	return source
+}
+
+// connectorFileChanges returns the configured files to upload, because they are new or their content changed, and the
+// names of the prior files to remove from the Source. Files are compared by their configured path, the Source only
+// knows their names.
+func connectorFileChanges(files, priorFiles []string, hashes, priorHashes map[string]string) ([]string, []string) {
+	var uploads []string
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = filepath.Base(file)
+		if priorHashes[file] != hashes[file] || !slices.Contains(priorFiles, file) {
+			uploads = append(uploads, file)
+		}
+	}
+	var removed []string
+	for _, file := range priorFiles {
+		if name := filepath.Base(file); !slices.Contains(names, name) {
+			removed = append(removed, name)
+		}
+	}
+	return uploads, removed
+}
+
+// connectorFilePaths maps the file names of the Source to the configured paths with the same name. Files of the Source
+// that are not configured keep their name.
+func connectorFilePaths(names []string, configured []string) []string {
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		file := name
+		for _, configuredFile := range configured {
+			if filepath.Base(configuredFile) == name {
+				file = configuredFile
+				break
+			}
+		}
+		paths = append(paths, file)
+	}
+	return paths
+}
+
+// removeConnectorFiles removes files from the connector files of the Source.
+func (r *sourceResource) removeConnectorFiles(ctx context.Context, source *sailpoint_v3.Source, removed []string, diagnostics *diag.Diagnostics) *sailpoint_v3.Source {
+	tflog.Info(ctx, "Removing connector files "+strings.Join(removed, ", "))
+	var remaining []string
+	if files, ok := source.ConnectorAttributes[connectorFilesAttribute].(string); ok {
+		for _, file := range strings.Split(files, ",") {
+			if file != "" && !slices.Contains(removed, file) {
+				remaining = append(remaining, file)
+			}
+		}
+	}
+	operation := sailpoint_v3.JsonPatchOperation{
+		Op:   "remove",
+		Path: "/connectorAttributes/" + connectorFilesAttribute,
+	}
+	if len(remaining) > 0 {
+		value := strings.Join(remaining, ",")
+		operation.Op = "replace"
+		operation.Value = &sailpoint_v3.JsonPatchOperationValue{String: &value}
+	}
+	updated, spResp, err := r.apiClient.V3.SourcesAPI.UpdateSource(ctx, *source.Id).JsonPatchOperation([]sailpoint_v3.JsonPatchOperation{operation}).Execute()
+	if err != nil {
+		diagnostics.AddError(
+			"Error Removing Connector Files",
+			"Could not remove connector files '"+strings.Join(removed, ", ")+"' from Source '"+*source.Id+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return source
+	}
+	return updated
+}
+
+// connectorFileHashes returns the SHA-256 of each connector file, keyed by the path as configured.
+func connectorFileHashes(files types.Set) (types.Map, error) {
+	if files.IsUnknown() {
+		return types.MapUnknown(types.StringType), nil
+	}
+	if files.IsNull() || len(files.Elements()) == 0 {
+		return types.MapNull(types.StringType), nil
+	}
+	hashes := make(map[string]attr.Value, len(files.Elements()))
+	for _, file := range getStringArray(files) {
+		if file == "" {
+			continue
+		}
+		hash, err := hashConnectorFile(file)
+		if err != nil {
+			return types.MapNull(types.StringType), err
+		}
+		hashes[file] = types.StringValue(hash)
+	}
+	return types.MapValueMust(types.StringType, hashes), nil
+}
+
+func hashConnectorFile(filePath string) (string, error) {
+	file, err := os.Open(resolveConnectorFile(filePath))
+	if err != nil {
+		return "", errors.New("Could not read connector file '" + filePath + "': " + err.Error())
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", errors.New("Could not read connector file '" + filePath + "': " + err.Error())
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// resolveConnectorFile returns the path of a connector file, files not found at their path are looked up in FILE_FOLDER.
+func resolveConnectorFile(filePath string) string {
+	if _, err := os.Stat(filePath); errors.Is(err, os.ErrNotExist) {
+		return filepath.FromSlash(FILE_FOLDER + "/" + filePath)
+	}
+	return filePath
+}
@@ -0,0 +1,121 @@
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sailpoint_v3 "github.com/sailpoint-oss/golang-sdk/v2/api_v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectorFileHashes(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "driver.jar")
+	assert.NoError(t, os.WriteFile(file, []byte("driver"), 0o600))
+	files := types.SetValueMust(types.StringType, []attr.Value{types.StringValue(file)})
+
+	hashes, err := connectorFileHashes(files)
+	assert.NoError(t, err)
+	assert.Equal(t, types.MapValueMust(types.StringType, map[string]attr.Value{
+		file: types.StringValue("b4def8217cadae26d4da633fd2a4e58e326cbb5d570afdc3989484da07af3579"),
+	}), hashes)
+
+	// A rebuilt file changes the hash
+	assert.NoError(t, os.WriteFile(file, []byte("rebuilt driver"), 0o600))
+	rebuilt, err := connectorFileHashes(files)
+	assert.NoError(t, err)
+	assert.False(t, hashes.Equal(rebuilt))
+
+	hashes, err = connectorFileHashes(types.SetNull(types.StringType))
+	assert.NoError(t, err)
+	assert.True(t, hashes.IsNull())
+
+	hashes, err = connectorFileHashes(types.SetUnknown(types.StringType))
+	assert.NoError(t, err)
+	assert.True(t, hashes.IsUnknown())
+
+	_, err = connectorFileHashes(types.SetValueMust(types.StringType, []attr.Value{types.StringValue("missing.jar")}))
+	assert.ErrorContains(t, err, "Could not read connector file 'missing.jar'")
+}
+
+func TestConnectorFileChanges(t *testing.T) {
+	hashes := map[string]string{"drivers/ojdbc.jar": "a", "lib/new.jar": "b"}
+
+	// An unchanged file under a subdirectory is not uploaded again
+	uploads, removed := connectorFileChanges([]string{"drivers/ojdbc.jar"}, []string{"drivers/ojdbc.jar"}, hashes, hashes)
+	assert.Empty(t, uploads)
+	assert.Empty(t, removed)
+
+	// New and rebuilt files are uploaded
+	uploads, removed = connectorFileChanges([]string{"drivers/ojdbc.jar", "lib/new.jar"}, []string{"drivers/ojdbc.jar"}, hashes,
+		map[string]string{"drivers/ojdbc.jar": "old"})
+	assert.Equal(t, []string{"drivers/ojdbc.jar", "lib/new.jar"}, uploads)
+	assert.Empty(t, removed)
+
+	// A dropped file is removed by name
+	uploads, removed = connectorFileChanges([]string{"lib/new.jar"}, []string{"drivers/ojdbc.jar", "lib/new.jar"}, hashes, hashes)
+	assert.Empty(t, uploads)
+	assert.Equal(t, []string{"ojdbc.jar"}, removed)
+
+	// A file moved to another directory is uploaded again but not removed
+	uploads, removed = connectorFileChanges([]string{"lib/ojdbc.jar"}, []string{"drivers/ojdbc.jar"},
+		map[string]string{"lib/ojdbc.jar": "a"}, hashes)
+	assert.Equal(t, []string{"lib/ojdbc.jar"}, uploads)
+	assert.Empty(t, removed)
+}
+
+func TestConnectorFilePaths(t *testing.T) {
+	assert.Equal(t, []string{"drivers/ojdbc.jar", "other.jar"}, connectorFilePaths([]string{"ojdbc.jar", "other.jar", ""}, []string{"drivers/ojdbc.jar"}))
+	assert.Empty(t, connectorFilePaths([]string{""}, nil))
+}
+
+// A file configured under a subdirectory keeps its path through Create, Read and Update, so it is not uploaded again.
+func TestConnectorFilesInSubdirectory(t *testing.T) {
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "drivers", "ojdbc.jar")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(file), 0o700))
+	assert.NoError(t, os.WriteFile(file, []byte("driver"), 0o600))
+	files := types.SetValueMust(types.StringType, []attr.Value{types.StringValue(file)})
+	hashes, err := connectorFileHashes(files)
+	assert.NoError(t, err)
+
+	id := "2c9180835d2e5168015d32f890ca1581"
+	threshold := int32(10)
+	source := &sailpoint_v3.Source{
+		Id:                  &id,
+		Name:                "Oracle",
+		DeleteThreshold:     &threshold,
+		ConnectorAttributes: map[string]interface{}{connectorFilesAttribute: "ojdbc.jar"},
+	}
+	r := &sourceResource{}
+
+	// Create
+	plan := sourceModel{ConnectorFiles: files, ConnectorFileHashes: hashes, ConnectorAttributes: jsontypes.NewNormalizedNull()}
+	var diagnostics diag.Diagnostics
+	r.mapToTerraformModel(&plan, source, &diagnostics)
+	assert.False(t, diagnostics.HasError())
+	assert.Equal(t, files, plan.ConnectorFiles)
+
+	// Read
+	state := plan
+	r.mapToTerraformModel(&state, source, &diagnostics)
+	assert.False(t, diagnostics.HasError())
+	assert.Equal(t, files, state.ConnectorFiles)
+
+	// Update, the client is not set so any upload or removal would fail
+	updated := state
+	updated.Description = types.StringValue("changed")
+	assert.Same(t, source, r.syncConnectorFiles(ctx, &updated, &state, source, &diagnostics))
+	assert.False(t, diagnostics.HasError())
+	assert.Equal(t, hashes, updated.ConnectorFileHashes)
+
+	// A file dropped in the UI is detected as drift
+	source.ConnectorAttributes[connectorFilesAttribute] = ""
+	r.mapToTerraformModel(&state, source, &diagnostics)
+	assert.True(t, state.ConnectorFiles.IsNull())
+}
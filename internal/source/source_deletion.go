@@ -0,0 +1,157 @@
+package source
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"terraform-provider-identitynow/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v2"
+	sailpoint_beta "github.com/sailpoint-oss/golang-sdk/v2/api_beta"
+	sailpoint_v3 "github.com/sailpoint-oss/golang-sdk/v2/api_v3"
+)
+
+// sourceDependant is an object that breaks, or is deleted with it, when the Source is deleted.
+type sourceDependant struct {
+	Type string
+	Name string
+	Id   string
+}
+
+func (d sourceDependant) String() string {
+	return d.Type + " '" + d.Name + "' (" + d.Id + ")"
+}
+
+// isDeletionProtected returns `deletion_protection`, which defaults to true for authoritative Sources.
+func isDeletionProtected(model *sourceModel) bool {
+	if !model.DeletionProtection.IsNull() {
+		return model.DeletionProtection.ValueBool()
+	}
+	return model.Authoritative.ValueBool()
+}
+
+// checkDeletable refuses the deletion of a protected Source or of a Source with dependants, unless `force_destroy` is set.
+// A partially created Source is tainted and may be deleted, nothing depends on it yet.
+func (r *sourceResource) checkDeletable(ctx context.Context, model *sourceModel, partiallyCreated bool, diagnostics *diag.Diagnostics) {
+	if partiallyCreated {
+		return
+	}
+	name := model.Name.ValueString()
+	if isDeletionProtected(model) {
+		diagnostics.AddError(
+			"Source Deletion Protected",
+			"Source '"+name+"' is protected against deletion, set `deletion_protection = false` and apply before deleting it.",
+		)
+		return
+	}
+	if model.ForceDestroy.ValueBool() {
+		return
+	}
+	dependants, spResp, err := r.findDependants(ctx, model.Id.ValueString())
+	if err != nil {
+		diagnostics.AddError(
+			"Error Deleting Source",
+			"Could not check dependants of Source '"+name+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+	if len(dependants) > 0 {
+		lines := make([]string, len(dependants))
+		for i, dependant := range dependants {
+			lines[i] = "- " + dependant.String()
+		}
+		diagnostics.AddError(
+			"Source Has Dependants",
+			"Source '"+name+"' is used by:\n"+strings.Join(lines, "\n")+"\nRemove them first or set `force_destroy = true` to delete it anyway.",
+		)
+	}
+}
+
+// findDependants returns the identity profiles, lifecycle states, roles and access profiles depending on the Source.
+func (r *sourceResource) findDependants(ctx context.Context, sourceId string) ([]sourceDependant, *http.Response, error) {
+	var dependants []sourceDependant
+
+	identityProfiles, spResp, err := sailpoint.PaginateWithDefaults[sailpoint_beta.IdentityProfile](r.apiClient.Beta.IdentityProfilesAPI.ListIdentityProfiles(ctx))
+	if err != nil {
+		return nil, spResp, err
+	}
+	for _, identityProfile := range identityProfiles {
+		if identityProfile.AuthoritativeSource.GetId() == sourceId {
+			dependants = append(dependants, sourceDependant{Type: "Identity Profile", Name: identityProfile.Name, Id: identityProfile.GetId()})
+		}
+		lifecycleStates, spResp, err := r.apiClient.V3.LifecycleStatesAPI.GetLifecycleStates(ctx, identityProfile.GetId()).Execute()
+		if err != nil {
+			return nil, spResp, err
+		}
+		for _, lifecycleState := range lifecycleStates {
+			if lifecycleStateDependsOnSource(lifecycleState, sourceId) {
+				dependants = append(dependants, sourceDependant{
+					Type: "Lifecycle State",
+					Name: identityProfile.Name + "/" + lifecycleState.Name,
+					Id:   lifecycleState.GetId(),
+				})
+			}
+		}
+	}
+
+	roles, spResp, err := sailpoint.Paginate[sailpoint_v3.Role](r.apiClient.V3.RolesAPI.ListRoles(ctx), 0, 50, 10000)
+	if err != nil {
+		return nil, spResp, err
+	}
+	for _, role := range roles {
+		if roleDependsOnSource(role, sourceId) {
+			dependants = append(dependants, sourceDependant{Type: "Role", Name: role.Name, Id: role.GetId()})
+		}
+	}
+
+	accessProfiles, spResp, err := sailpoint.Paginate[sailpoint_v3.AccessProfile](
+		r.apiClient.V3.AccessProfilesAPI.ListAccessProfiles(ctx).Filters(util.FilterEquals("source.id", sourceId)), 0, 50, 10000)
+	if err != nil {
+		return nil, spResp, err
+	}
+	for _, accessProfile := range accessProfiles {
+		dependants = append(dependants, sourceDependant{Type: "Access Profile", Name: accessProfile.Name, Id: accessProfile.GetId()})
+	}
+	return dependants, nil, nil
+}
+
+func lifecycleStateDependsOnSource(lifecycleState sailpoint_v3.LifecycleState, sourceId string) bool {
+	for _, action := range lifecycleState.AccountActions {
+		if slices.Contains(action.SourceIds, sourceId) {
+			return true
+		}
+	}
+	return false
+}
+
+// roleDependsOnSource returns whether the membership criteria of the role test accounts or entitlements of the Source.
+func roleDependsOnSource(role sailpoint_v3.Role, sourceId string) bool {
+	membership := role.Membership.Get()
+	if membership == nil || membership.Criteria.Get() == nil {
+		return false
+	}
+	level1 := membership.Criteria.Get()
+	if criteriaKeyUsesSource(level1.Key.Get(), sourceId) {
+		return true
+	}
+	for _, level2 := range level1.Children {
+		if criteriaKeyUsesSource(level2.Key.Get(), sourceId) {
+			return true
+		}
+		for _, level3 := range level2.Children {
+			if criteriaKeyUsesSource(level3.Key.Get(), sourceId) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func criteriaKeyUsesSource(key *sailpoint_v3.RoleCriteriaKey, sourceId string) bool {
+	if key == nil || (key.Type != sailpoint_v3.ROLECRITERIAKEYTYPE_ACCOUNT && key.Type != sailpoint_v3.ROLECRITERIAKEYTYPE_ENTITLEMENT) {
+		return false
+	}
+	return key.SourceId.Get() != nil && *key.SourceId.Get() == sourceId
+}
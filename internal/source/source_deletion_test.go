@@ -0,0 +1,75 @@
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sailpoint_v3 "github.com/sailpoint-oss/golang-sdk/v2/api_v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDeletionProtected(t *testing.T) {
+	assert.True(t, isDeletionProtected(&sourceModel{Authoritative: types.BoolValue(true)}))
+	assert.False(t, isDeletionProtected(&sourceModel{Authoritative: types.BoolValue(false)}))
+	assert.False(t, isDeletionProtected(&sourceModel{Authoritative: types.BoolValue(true), DeletionProtection: types.BoolValue(false)}))
+	assert.True(t, isDeletionProtected(&sourceModel{Authoritative: types.BoolValue(false), DeletionProtection: types.BoolValue(true)}))
+}
+
+func TestCheckDeletablePartiallyCreated(t *testing.T) {
+	ctx := context.Background()
+	r := &sourceResource{}
+	model := &sourceModel{Name: types.StringValue("HR"), Authoritative: types.BoolValue(true), DeletionProtection: types.BoolNull()}
+
+	var diagnostics diag.Diagnostics
+	r.checkDeletable(ctx, model, false, &diagnostics)
+	assert.True(t, diagnostics.HasError())
+	assert.Equal(t, "Source Deletion Protected", diagnostics.Errors()[0].Summary())
+
+	// A tainted Source is deleted without checking its protection or dependants
+	diagnostics = nil
+	r.checkDeletable(ctx, model, true, &diagnostics)
+	assert.False(t, diagnostics.HasError())
+}
+
+func TestLifecycleStateDependsOnSource(t *testing.T) {
+	lifecycleState := sailpoint_v3.LifecycleState{
+		AccountActions: []sailpoint_v3.AccountAction{{SourceIds: []string{"other"}}, {SourceIds: []string{"source"}}},
+	}
+	assert.True(t, lifecycleStateDependsOnSource(lifecycleState, "source"))
+	assert.False(t, lifecycleStateDependsOnSource(lifecycleState, "unused"))
+	assert.False(t, lifecycleStateDependsOnSource(sailpoint_v3.LifecycleState{}, "source"))
+}
+
+func TestRoleDependsOnSource(t *testing.T) {
+	key := func(keyType sailpoint_v3.RoleCriteriaKeyType, sourceId string) sailpoint_v3.NullableRoleCriteriaKey {
+		return *sailpoint_v3.NewNullableRoleCriteriaKey(&sailpoint_v3.RoleCriteriaKey{
+			Type:     keyType,
+			Property: "attribute",
+			SourceId: *sailpoint_v3.NewNullableString(&sourceId),
+		})
+	}
+	role := func(criteria *sailpoint_v3.RoleCriteriaLevel1) sailpoint_v3.Role {
+		return sailpoint_v3.Role{Membership: *sailpoint_v3.NewNullableRoleMembershipSelector(&sailpoint_v3.RoleMembershipSelector{
+			Criteria: *sailpoint_v3.NewNullableRoleCriteriaLevel1(criteria),
+		})}
+	}
+
+	assert.True(t, roleDependsOnSource(role(&sailpoint_v3.RoleCriteriaLevel1{
+		Key: key(sailpoint_v3.ROLECRITERIAKEYTYPE_ENTITLEMENT, "source"),
+	}), "source"))
+	assert.True(t, roleDependsOnSource(role(&sailpoint_v3.RoleCriteriaLevel1{
+		Children: []sailpoint_v3.RoleCriteriaLevel2{{
+			Children: []sailpoint_v3.RoleCriteriaLevel3{{Key: key(sailpoint_v3.ROLECRITERIAKEYTYPE_ACCOUNT, "source")}},
+		}},
+	}), "source"))
+	// Identity attributes do not depend on a source
+	assert.False(t, roleDependsOnSource(role(&sailpoint_v3.RoleCriteriaLevel1{
+		Key: key(sailpoint_v3.ROLECRITERIAKEYTYPE_IDENTITY, "source"),
+	}), "source"))
+	assert.False(t, roleDependsOnSource(role(&sailpoint_v3.RoleCriteriaLevel1{
+		Key: key(sailpoint_v3.ROLECRITERIAKEYTYPE_ENTITLEMENT, "other"),
+	}), "source"))
+	assert.False(t, roleDependsOnSource(sailpoint_v3.Role{}, "source"))
+}
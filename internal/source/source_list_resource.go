@@ -0,0 +1,67 @@
+package source
+
+import (
+	"context"
+	"terraform-provider-identitynow/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v2"
+	sailpoint_v3 "github.com/sailpoint-oss/golang-sdk/v2/api_v3"
+)
+
+// Implementation of IdentityNow Source listing for `terraform query` - https://developer.sailpoint.com/idn/api/v3/list-sources
+var (
+	_ list.ListResource              = &sourceListResource{}
+	_ list.ListResourceWithConfigure = &sourceListResource{}
+)
+
+func NewSourceListResource() list.ListResource {
+	return &sourceListResource{}
+}
+
+// sourceListResource shares Configure, Metadata and the state mapping with the managed resource.
+type sourceListResource struct {
+	sourceResource
+}
+
+func (r *sourceListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = util.ListFiltersSchema(`type eq "DelimitedFile"`)
+}
+
+func (r *sourceListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config util.ListFiltersModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	listRequest := r.apiClient.V3.SourcesAPI.ListSources(ctx)
+	if !config.Filters.IsNull() {
+		listRequest = listRequest.Filters(config.Filters.ValueString())
+	}
+	sources, spResp, err := sailpoint.PaginateWithDefaults[sailpoint_v3.Source](listRequest)
+	if err != nil {
+		diags.AddError(
+			"Error Listing Sources",
+			"Could not list Sources: "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = util.StreamListResults(ctx, req, sources, func(source *sailpoint_v3.Source, result *list.ListResult) {
+		result.DisplayName = source.Name
+		util.SetIdResourceIdentity(ctx, result.Identity, types.StringPointerValue(source.Id), &result.Diagnostics)
+		if !req.IncludeResource {
+			return
+		}
+		model := r.newImportModel(source, &result.Diagnostics)
+		r.mapToTerraformModel(&model, source, &result.Diagnostics)
+		if result.Diagnostics.HasError() {
+			return
+		}
+		result.Diagnostics.Append(result.Resource.Set(ctx, &model)...)
+	})
+}
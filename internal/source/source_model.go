@@ -8,32 +8,42 @@ import (
 )
 
 type sourceModel struct {
-	Id                             types.String             `tfsdk:"id"`
-	Name                           types.String             `tfsdk:"name"`
-	Description                    types.String             `tfsdk:"description"`
-	Owner                          *util.ReferenceModel     `tfsdk:"owner"`
-	Cluster                        types.Object             `tfsdk:"cluster"`
-	AccountCorrelationConfig       *util.ReferenceModel     `tfsdk:"account_correlation_config"`
-	AccountCorrelationRule         *util.ReferenceModel     `tfsdk:"account_correlation_rule"`
-	ManagerCorrelationMapping      *managerCorrelationModel `tfsdk:"manager_correlation_mapping"`
-	ManagerCorrelationRule         *util.ReferenceModel     `tfsdk:"manager_correlation_rule"`
-	BeforeProvisioningRule         *util.ReferenceModel     `tfsdk:"before_provisioning_rule"`
-	PasswordPolicies               []util.ReferenceModel    `tfsdk:"password_policies"`
-	Features                       types.Set                `tfsdk:"features"`
-	Type                           types.String             `tfsdk:"type"`
-	Connector                      types.String             `tfsdk:"connector"`
-	ConnectorClass                 types.String             `tfsdk:"connector_class"`
-	ConnectorAttributes            jsontypes.Normalized     `tfsdk:"connector_attributes"`
-	ConnectorAttributesCredentials jsontypes.Exact          `tfsdk:"connector_attributes_credentials"`
-	DeleteThreshold                types.Int64              `tfsdk:"delete_threshold"`
-	Authoritative                  types.Bool               `tfsdk:"authoritative"`
-	ManagementWorkgroup            *util.ReferenceModel     `tfsdk:"management_workgroup"`
-	Status                         types.String             `tfsdk:"status"`
-	ConnectorId                    types.String             `tfsdk:"connector_id"`
-	ConnectorName                  types.String             `tfsdk:"connector_name"`
-	ConnectionType                 types.String             `tfsdk:"connection_type"`
-	ConnectorImplementationId      types.String             `tfsdk:"connector_implementation_id"`
-	ConnectorFiles                 types.Set                `tfsdk:"connector_files"`
+	Id                               types.String             `tfsdk:"id"`
+	Name                             types.String             `tfsdk:"name"`
+	Description                      types.String             `tfsdk:"description"`
+	Owner                            *util.ReferenceModel     `tfsdk:"owner"`
+	Cluster                          types.Object             `tfsdk:"cluster"`
+	AccountCorrelationConfig         *util.ReferenceModel     `tfsdk:"account_correlation_config"`
+	AccountCorrelationRule           *util.ReferenceModel     `tfsdk:"account_correlation_rule"`
+	ManagerCorrelationMapping        *managerCorrelationModel `tfsdk:"manager_correlation_mapping"`
+	ManagerCorrelationRule           *util.ReferenceModel     `tfsdk:"manager_correlation_rule"`
+	BeforeProvisioningRule           *util.ReferenceModel     `tfsdk:"before_provisioning_rule"`
+	PasswordPolicies                 []util.ReferenceModel    `tfsdk:"password_policies"`
+	Features                         types.Set                `tfsdk:"features"`
+	Type                             types.String             `tfsdk:"type"`
+	Connector                        types.String             `tfsdk:"connector"`
+	ConnectorClass                   types.String             `tfsdk:"connector_class"`
+	ConnectorAttributes              jsontypes.Normalized     `tfsdk:"connector_attributes"`
+	ConnectorAttributesCredentials   jsontypes.Exact          `tfsdk:"connector_attributes_credentials"`
+	ConnectorAttributesCredentialsWo jsontypes.Exact          `tfsdk:"connector_attributes_credentials_wo"`
+	CredentialsVersion               types.Int64              `tfsdk:"credentials_version"`
+	DeleteThreshold                  types.Int64              `tfsdk:"delete_threshold"`
+	Authoritative                    types.Bool               `tfsdk:"authoritative"`
+	ManagementWorkgroup              *util.ReferenceModel     `tfsdk:"management_workgroup"`
+	Status                           types.String             `tfsdk:"status"`
+	ConnectorId                      types.String             `tfsdk:"connector_id"`
+	ConnectorName                    types.String             `tfsdk:"connector_name"`
+	ConnectionType                   types.String             `tfsdk:"connection_type"`
+	ConnectorImplementationId        types.String             `tfsdk:"connector_implementation_id"`
+	ConnectorFiles                   types.Set                `tfsdk:"connector_files"`
+	ConnectorFileHashes              types.Map                `tfsdk:"connector_file_hashes"`
+	TestConnection                   types.Bool               `tfsdk:"test_connection"`
+	TestConnectionSeverity           types.String             `tfsdk:"test_connection_severity"`
+	TestConnectionResult             types.String             `tfsdk:"test_connection_result"`
+	TestConnectionTimestamp          types.String             `tfsdk:"test_connection_timestamp"`
+	DeletionProtection               types.Bool               `tfsdk:"deletion_protection"`
+	ForceDestroy                     types.Bool               `tfsdk:"force_destroy"`
+	ValidateConnectorAttributes      types.Bool               `tfsdk:"validate_connector_attributes"`
 }
 
 type managerCorrelationModel struct {
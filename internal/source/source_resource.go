@@ -4,18 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
-	"path/filepath"
 	"reflect"
+	"slices"
 	"strings"
 	"terraform-provider-identitynow/internal/patch"
 	"terraform-provider-identitynow/internal/sailpoint/custom"
 	"terraform-provider-identitynow/internal/util"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
@@ -35,18 +38,25 @@ import (
 
 // Implementation of IdentityNow Source CRUD - https://developer.sailpoint.com/idn/api/v3/create-source
 var (
-	_ resource.Resource              = &sourceResource{}
-	_ resource.ResourceWithConfigure = &sourceResource{}
+	_ resource.Resource                = &sourceResource{}
+	_ resource.ResourceWithConfigure   = &sourceResource{}
+	_ resource.ResourceWithImportState = &sourceResource{}
+	_ resource.ResourceWithIdentity    = &sourceResource{}
+	_ resource.ResourceWithModifyPlan  = &sourceResource{}
 )
 
 const FILE_FOLDER = "files"
 
+// Connector attributes maintained by IdentityNow itself, they are not imported into `connector_attributes`.
+var importIgnoredConnectorAttributes = []string{"cloudExternalId", "cloudDisplayName", "cloudCacheUpdate", "connector_files"}
+
 func NewSourceResource() resource.Resource {
 	return &sourceResource{}
 }
 
 type sourceResource struct {
-	apiClient *sailpoint.APIClient
+	apiClient    *sailpoint.APIClient
+	customClient *custom.APIClient
 }
 
 func (r *sourceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -63,12 +73,17 @@ func (r *sourceResource) Configure(ctx context.Context, req resource.ConfigureRe
 	}
 
 	r.apiClient = client.ApiClient
+	r.customClient = client
 }
 
 func (r *sourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_source"
 }
 
+func (r *sourceResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = util.IdResourceIdentitySchema()
+}
+
 func (r *sourceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
@@ -154,7 +169,7 @@ func (r *sourceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"connector_attributes": schema.StringAttribute{
-				Description: "Connector specific configuration; will differ from type to type",
+				Description: "Connector specific configuration; will differ from type to type; validated against the attributes of the connector at plan time",
 				CustomType:  jsontypes.NormalizedType{},
 				Optional:    true,
 				Computed:    true,
@@ -165,6 +180,23 @@ func (r *sourceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"connector_attributes_credentials_wo": schema.StringAttribute{
+				Description: "Write-only variant of `connector_attributes_credentials` that is never stored in state; will be merged with `connector_attributes` on create and whenever `credentials_version` changes",
+				CustomType:  jsontypes.ExactType{},
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("connector_attributes_credentials")),
+				},
+			},
+			"credentials_version": schema.Int64Attribute{
+				Description: "Version of `connector_attributes_credentials_wo`, changing it sends the credentials again",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("connector_attributes_credentials_wo")),
+				},
+			},
 			"delete_threshold": schema.Int64Attribute{
 				Description: "Number from 0 to 100 that specifies when to skip the delete phase",
 				Optional:    true,
@@ -220,20 +252,83 @@ func (r *sourceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"connector_files": schema.SetAttribute{
-				Description: "This uploads a supplemental source connector file (like jdbc driver jars) to a source's S3 bucket. Files must be located in the same folder or in folder 'files'.",
+				Description: "This uploads a supplemental source connector file (like jdbc driver jars) to a source's S3 bucket. Files must be located in the same folder or in folder 'files'. Files are uploaded again when their content changes and removed from the source when dropped from the set.",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"connector_file_hashes": schema.MapAttribute{
+				Description: "The SHA-256 of each file of `connector_files`, keyed by the path as configured",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"test_connection": schema.BoolAttribute{
+				Description: "Test the connection of the source after create and update, so a misconfigured connection is reported before the first aggregation",
+				Optional:    true,
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Refuse to delete the source, defaults to `authoritative`. Set to false and apply before deleting the source. Sources tainted by a failed create are not protected",
+				Optional:    true,
+			},
+			"force_destroy": schema.BoolAttribute{
+				Description: "Delete the source even when identity profiles, lifecycle states, roles or access profiles depend on it, those are otherwise reported and the deletion is refused",
+				Optional:    true,
+			},
+			"validate_connector_attributes": schema.BoolAttribute{
+				Description: "Validate `connector_attributes` against the attributes of the connector at plan time, defaults to true. Disable it when the connector specification does not match the attributes the source needs",
+				Optional:    true,
+			},
+			"test_connection_severity": schema.StringAttribute{
+				Description: "Severity of a failed connection test, `error` (default) or `warning`. A source failing its test on create is tainted on `error`",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(connectionTestSeverityError),
+				Validators: []validator.String{
+					stringvalidator.OneOf(connectionTestSeverityError, connectionTestSeverityWarning),
+				},
+			},
+			"test_connection_result": schema.StringAttribute{
+				Description: "Result of the last connection test, `SUCCESS` or the message of the connector",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					keepConnectionTestResult{},
+				},
+			},
+			"test_connection_timestamp": schema.StringAttribute{
+				Description: "Time of the last connection test in RFC 3339 format",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					keepConnectionTestResult{},
+				},
+			},
 		},
 	}
 }
 
+func (r *sourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	// Changed connector attributes are checked against the specification of the connector
+	if req.State.Raw.IsNull() || !req.Plan.Raw.Equal(req.State.Raw) {
+		r.checkConnectorAttributes(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	r.planConnectorFileHashes(ctx, req, resp)
+}
+
 func (r *sourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan sourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	// Write-only credentials are only part of the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("connector_attributes_credentials_wo"), &plan.ConnectorAttributesCredentialsWo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	source := r.convertToCreateAPIModel(&plan)
 	if resp.Diagnostics.HasError() {
 		return
@@ -253,6 +348,14 @@ func (r *sourceResource) Create(ctx context.Context, req resource.CreateRequest,
 	if !util.IsNil(source.Connector) {
 		provisionAsCsv = (source.Connector == "delimited-file-angularsc")
 	}
+	// Connector attributes are set by a patch after the creation
+	createdSource := source
+	createdSource.ConnectorAttributes = make(map[string]interface{})
+	jsonPatch := r.generateJsonPatch(&newModel, &createdSource, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Info(ctx, fmt.Sprintf("Creating source '%s': %s", source.Name, util.PrettyPrint(source)))
 	sourceResponse, spResp, err := r.apiClient.V3.SourcesAPI.CreateSource(ctx).Source(source).ProvisionAsCsv(provisionAsCsv).Execute()
 	if err != nil {
@@ -263,12 +366,6 @@ func (r *sourceResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	source.ConnectorAttributes = make(map[string]interface{})
-	jsonPatch := r.generateJsonPatch(&newModel, &source, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
-		r.apiClient.V3.SourcesAPI.DeleteSource(ctx, *sourceResponse.Id).Execute()
-		return
-	}
 	tflog.Info(ctx, fmt.Sprintf("Modifying source '%s': %s", source.Name, util.PrettyPrint(jsonPatch)))
 	sourceResponseAfterPatch, spResp, err := r.apiClient.V3.SourcesAPI.UpdateSource(ctx, *sourceResponse.Id).JsonPatchOperation(jsonPatch).Execute()
 	if err != nil {
@@ -276,39 +373,62 @@ func (r *sourceResource) Create(ctx context.Context, req resource.CreateRequest,
 			"Error Creating Source",
 			"Could not update Source '"+plan.Name.ValueString()+"': "+err.Error()+"\n"+util.GetBody(spResp),
 		)
-		r.apiClient.V3.SourcesAPI.DeleteSource(ctx, *sourceResponse.Id).Execute()
+		r.savePartiallyCreated(ctx, &plan, sourceResponse, resp)
 		return
 	}
-	if !plan.ConnectorFiles.IsUnknown() && !plan.ConnectorFiles.IsNull() && len(plan.ConnectorFiles.Elements()) > 0 {
-		var sourceFilUploadResponse *sailpoint_v3.Source = nil
-		for _, element := range plan.ConnectorFiles.Elements() {
-			filePath := element.(basetypes.StringValue)
-			sourceFilUploadResponse = r.uploadConnectorFiles(ctx, *sourceResponse.Id, filePath.ValueString(), &resp.Diagnostics)
-		}
-		if sourceFilUploadResponse != nil {
-			r.mapToTerraformModel(&plan, sourceFilUploadResponse, &resp.Diagnostics)
-		}
-	} else {
-		r.mapToTerraformModel(&plan, sourceResponseAfterPatch, &resp.Diagnostics)
+	sourceResponseAfterPatch = r.syncConnectorFiles(ctx, &plan, nil, sourceResponseAfterPatch, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		r.savePartiallyCreated(ctx, &plan, sourceResponseAfterPatch, resp)
+		return
 	}
+	r.mapToTerraformModel(&plan, sourceResponseAfterPatch, &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	// A failed test is saved with the Source, so Terraform taints it
+	r.testConnection(ctx, &plan, nil, &resp.Diagnostics)
+
+	util.SetIdResourceIdentity(ctx, resp.Identity, plan.Id, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(util.MarkCreated(ctx, resp.Private)...)
 
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
+// savePartiallyCreated keeps the created Source in state after a failed Create, so Terraform taints it instead of losing track of it.
+func (r *sourceResource) savePartiallyCreated(ctx context.Context, plan *sourceModel, source *sailpoint_v3.Source, resp *resource.CreateResponse) {
+	var diagnostics diag.Diagnostics
+	r.mapToTerraformModel(plan, source, &diagnostics)
+	// The connection is not tested when the Source could not be configured
+	plan.TestConnectionResult = types.StringNull()
+	plan.TestConnectionTimestamp = types.StringNull()
+	// The connector files may not have been uploaded
+	plan.ConnectorFileHashes = types.MapNull(types.StringType)
+	util.SetIdResourceIdentity(ctx, resp.Identity, plan.Id, &diagnostics)
+	diagnostics.Append(util.MarkCreated(ctx, resp.Private)...)
+	// Destroying the tainted Source is not blocked by its deletion protection
+	diagnostics.Append(util.MarkPartiallyCreated(ctx, resp.Private)...)
+	diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(diagnostics...)
+	if !diagnostics.HasError() {
+		util.AddPartiallyCreatedWarning(&resp.Diagnostics, "Source", plan.Id.ValueString())
+	}
+}
+
 func (r *sourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state sourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	util.SetIdResourceIdentity(ctx, resp.Identity, state.Id, &resp.Diagnostics)
 
-	source, spResp, err := r.apiClient.V3.SourcesAPI.GetSource(ctx, state.Id.ValueString()).Execute()
-	if spResp.StatusCode == 404 {
+	source, spResp, err := util.ReadAfterWrite(ctx, req.Private, func() (*sailpoint_v3.Source, *http.Response, error) {
+		return r.apiClient.V3.SourcesAPI.GetSource(ctx, state.Id.ValueString()).Execute()
+	})
+	if util.IsNotFound(spResp) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -336,6 +456,13 @@ func (r *sourceResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	// Write-only credentials are sent again only for a new version, without them the patch keeps the credentials of the Source
+	if !plan.CredentialsVersion.Equal(state.CredentialsVersion) {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("connector_attributes_credentials_wo"), &plan.ConnectorAttributesCredentialsWo)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	newModel := r.convertToAPIModel(&plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -349,8 +476,16 @@ func (r *sourceResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	tflog.Info(ctx, fmt.Sprintf("Updating source '%s' with json patch: %s", state.Id.ValueString(), util.PrettyPrint(jsonPatch)))
-	sourceResponse, spResp, err := r.apiClient.V3.SourcesAPI.UpdateSource(ctx, state.Id.ValueString()).JsonPatchOperation(jsonPatch).Execute()
+	var sourceResponse *sailpoint_v3.Source
+	var spResp *http.Response
+	var err error
+	if len(jsonPatch) > 0 {
+		tflog.Info(ctx, fmt.Sprintf("Updating source '%s' with json patch: %s", state.Id.ValueString(), util.PrettyPrint(jsonPatch)))
+		sourceResponse, spResp, err = r.apiClient.V3.SourcesAPI.UpdateSource(ctx, state.Id.ValueString()).JsonPatchOperation(jsonPatch).Execute()
+	} else {
+		// Only provider side attributes like `deletion_protection` changed
+		sourceResponse, spResp, err = r.apiClient.V3.SourcesAPI.GetSource(ctx, state.Id.ValueString()).Execute()
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Source",
@@ -359,23 +494,18 @@ func (r *sourceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	connectorFileDifference := r.getDifference(state.ConnectorFiles, plan.ConnectorFiles)
-	if len(connectorFileDifference) > 0 {
-		var uploadConnectorFiles *sailpoint_v3.Source
-		for _, file := range connectorFileDifference {
-			uploadConnectorFiles = r.uploadConnectorFiles(ctx, state.Id.ValueString(), file, &resp.Diagnostics)
-
-		}
-		if uploadConnectorFiles != nil {
-			r.mapToTerraformModel(&plan, uploadConnectorFiles, &resp.Diagnostics)
-		}
-	} else {
-		r.mapToTerraformModel(&plan, sourceResponse, &resp.Diagnostics)
+	sourceResponse = r.syncConnectorFiles(ctx, &plan, &state, sourceResponse, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	r.mapToTerraformModel(&plan, sourceResponse, &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	r.testConnection(ctx, &plan, &state, &resp.Diagnostics)
+
+	util.SetIdResourceIdentity(ctx, resp.Identity, plan.Id, &resp.Diagnostics)
 
 	// Set refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -388,6 +518,11 @@ func (r *sourceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	r.checkDeletable(ctx, &state, util.IsPartiallyCreated(ctx, req.Private), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Info(ctx, fmt.Sprintf("Deleting source '%s'", state.Id.ValueString()))
 	taskResult, spResp, err := r.apiClient.V3.SourcesAPI.DeleteSource(ctx, state.Id.ValueString()).Execute()
 	if err != nil {
@@ -558,7 +693,11 @@ func (r *sourceResource) getConnectorAttributes(model *sourceModel, diagnostics
 	if diagnostics.HasError() {
 		return nil
 	}
-	return r.mergeMaps(connectorAttributesCred, connectorAttributes)
+	connectorAttributesCredWo := util.UnmarshalJsonType(model.ConnectorAttributesCredentialsWo, diagnostics)
+	if diagnostics.HasError() {
+		return nil
+	}
+	return r.mergeMaps(connectorAttributesCredWo, r.mergeMaps(connectorAttributesCred, connectorAttributes))
 }
 
 func (r *sourceResource) mergeMaps(connectorAttributesCred map[string]interface{}, connectorAttributes map[string]interface{}) map[string]interface{} {
@@ -596,8 +735,9 @@ func (r *sourceResource) mergeSlices(leftSlice, rightSlice []interface{}) []inte
 func (r *sourceResource) mapToTerraformModel(tfModel *sourceModel, source *sailpoint_v3.Source, diagnostics *diag.Diagnostics) {
 	tfModel.Id = types.StringPointerValue(source.Id)
 	if val, ok := source.ConnectorAttributes["connector_files"]; ok {
-		if strVal, ok := val.(string); ok {
-			files := strings.Split(strVal, ",")
+		if strVal, ok := val.(string); ok && strVal != "" {
+			// The Source only knows the names of the files, configured paths are kept
+			files := connectorFilePaths(strings.Split(strVal, ","), getStringArray(tfModel.ConnectorFiles))
 			connectorFiles := make([]attr.Value, len(files))
 			for i, file := range files {
 				connectorFiles[i] = types.StringValue(file)
@@ -703,9 +843,7 @@ func (r *sourceResource) uploadConnectorFiles(ctx context.Context, sourceId stri
 	if filePath == "" {
 		return nil
 	}
-	if _, err := os.Stat(filePath); errors.Is(err, os.ErrNotExist) {
-		filePath = filepath.FromSlash(FILE_FOLDER + "/" + filePath)
-	}
+	filePath = resolveConnectorFile(filePath)
 	file, err := os.Open(filePath)
 	if errors.Is(err, os.ErrNotExist) {
 		diagnostics.AddError(
@@ -725,12 +863,6 @@ func (r *sourceResource) uploadConnectorFiles(ctx context.Context, sourceId stri
 	return source
 }
 
-func (r *sourceResource) getDifference(current, desired types.Set) []string {
-	currentArray := getStringArray(current)
-	desiredArray := getStringArray(desired)
-	return getDifference(desiredArray, currentArray)
-}
-
 func getStringArray(set types.Set) []string {
 	var array []string
 	for _, item := range set.Elements() {
@@ -739,17 +871,59 @@ func getStringArray(set types.Set) []string {
 	return array
 }
 
-func getDifference(a, b []string) (diff []string) {
-	m := make(map[string]bool)
+func (r *sourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	key := util.ParseImportKey(util.GetImportId(ctx, req, &resp.Diagnostics), "source")
+	var source *sailpoint_v3.Source
+	var spResp *http.Response
+	var err error
+	if key.IsName() {
+		source, spResp, err = util.FindSourceByName(ctx, r.apiClient, key.Name)
+	} else {
+		source, spResp, err = r.apiClient.V3.SourcesAPI.GetSource(ctx, key.Id).Execute()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Source",
+			"Could not find Source '"+key.String()+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
 
-	for _, item := range b {
-		m[item] = true
+	state := r.newImportModel(source, &resp.Diagnostics)
+	r.mapToTerraformModel(&state, source, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	util.SetIdResourceIdentity(ctx, resp.Identity, state.Id, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
 
-	for _, item := range a {
-		if _, ok := m[item]; !ok {
-			diff = append(diff, item)
+// newImportModel prepares the model, so mapToTerraformModel maps all optional references and connector attributes of the imported Source.
+func (r *sourceResource) newImportModel(source *sailpoint_v3.Source, diagnostics *diag.Diagnostics) sourceModel {
+	connectorAttributes := make(map[string]interface{})
+	for key, value := range source.ConnectorAttributes {
+		if !slices.Contains(importIgnoredConnectorAttributes, key) {
+			connectorAttributes[key] = value
 		}
 	}
-	return
+	model := sourceModel{
+		ConnectorAttributes:              util.MarshalToJsonTypeNormalized(connectorAttributes, diagnostics),
+		ConnectorAttributesCredentials:   jsontypes.NewExactNull(),
+		ConnectorAttributesCredentialsWo: jsontypes.NewExactNull(),
+		TestConnectionSeverity:           types.StringValue(connectionTestSeverityError),
+		ConnectorFileHashes:              types.MapNull(types.StringType),
+	}
+	if source.AccountCorrelationConfig.Get() != nil {
+		model.AccountCorrelationConfig = &util.ReferenceModel{}
+	}
+	if source.AccountCorrelationRule.Get() != nil {
+		model.AccountCorrelationRule = &util.ReferenceModel{}
+	}
+	if source.ManagerCorrelationRule.Get() != nil {
+		model.ManagerCorrelationRule = &util.ReferenceModel{}
+	}
+	if source.BeforeProvisioningRule.Get() != nil {
+		model.BeforeProvisioningRule = &util.ReferenceModel{}
+	}
+	return model
 }
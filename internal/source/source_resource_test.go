@@ -84,6 +84,26 @@ func Test_sourceResource_getConnectorAttributes(t *testing.T) {
 				"second": []interface{}{"c", "d", "e"},
 			},
 		},
+		{
+			name: "Test Merge Of Write-Only Connector Attributes", fields: fields{apiClient: nil},
+			args: struct {
+				model       *sourceModel
+				diagnostics *diag.Diagnostics
+			}{
+				model: &sourceModel{
+					ConnectorAttributes:              jsontypes.NewNormalizedValue("{\"user\":\"svc\", \"innerObject\":{\"innerKey\":\"innerValue\"}}"),
+					ConnectorAttributesCredentialsWo: jsontypes.NewExactValue("{\"password\":\"pass\", \"innerObject\":{\"innerPass\":\"pass\"}}"),
+				},
+				diagnostics: &diag.Diagnostics{}},
+			want: map[string]interface{}{
+				"user":     "svc",
+				"password": "pass",
+				"innerObject": map[string]interface{}{
+					"innerKey":  "innerValue",
+					"innerPass": "pass",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,101 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"terraform-provider-identitynow/internal/util"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sailpointBeta "github.com/sailpoint-oss/golang-sdk/v2/api_beta"
+)
+
+const (
+	connectionTestSeverityError   = "error"
+	connectionTestSeverityWarning = "warning"
+	connectionTestResultSuccess   = "SUCCESS"
+)
+
+// testConnection checks the connection of the source when `test_connection` is enabled and records the result. A failed
+// check is reported with the message of the connector, as an error or a warning depending on `test_connection_severity`.
+// Without a check the result of prior is kept.
+func (r *sourceResource) testConnection(ctx context.Context, model *sourceModel, prior *sourceModel, diagnostics *diag.Diagnostics) {
+	if !model.TestConnection.ValueBool() {
+		model.TestConnectionResult = types.StringNull()
+		model.TestConnectionTimestamp = types.StringNull()
+		if prior != nil {
+			model.TestConnectionResult = prior.TestConnectionResult
+			model.TestConnectionTimestamp = prior.TestConnectionTimestamp
+		}
+		return
+	}
+
+	tflog.Info(ctx, "Testing connection of Source '"+model.Name.ValueString()+"'")
+	status, spResp, err := r.apiClient.Beta.SourcesAPI.TestSourceConnection(ctx, model.Id.ValueString()).Execute()
+	model.TestConnectionTimestamp = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	var failure string
+	if err != nil {
+		failure = err.Error() + "\n" + util.GetBody(spResp)
+	} else {
+		failure = connectionTestFailure(status)
+	}
+	if failure == "" {
+		model.TestConnectionResult = types.StringValue(connectionTestResultSuccess)
+		return
+	}
+	model.TestConnectionResult = types.StringValue(failure)
+
+	summary := "Source Connection Test Failed"
+	detail := "Connection test of Source '" + model.Name.ValueString() + "' failed: " + failure
+	if model.TestConnectionSeverity.ValueString() == connectionTestSeverityWarning {
+		diagnostics.AddAttributeWarning(path.Root("test_connection"), summary, detail)
+	} else {
+		diagnostics.AddAttributeError(path.Root("test_connection"), summary, detail)
+	}
+}
+
+// connectionTestFailure returns the message of a failed connection test, or an empty string when the test succeeded.
+func connectionTestFailure(status *sailpointBeta.StatusResponse) string {
+	if status == nil || status.GetStatus() == connectionTestResultSuccess {
+		return ""
+	}
+	for _, key := range []string{"error", "message", "exception"} {
+		if message, ok := status.Details[key].(string); ok && message != "" {
+			return message
+		}
+	}
+	if len(status.Details) > 0 {
+		if details, err := json.Marshal(status.Details); err == nil {
+			return "status " + status.GetStatus() + ": " + string(details)
+		}
+	}
+	return "status " + status.GetStatus()
+}
+
+// keepConnectionTestResult keeps the recorded connection test result in the plan while `test_connection` is disabled,
+// the result is known only after apply when the connection is tested.
+type keepConnectionTestResult struct{}
+
+func (m keepConnectionTestResult) Description(_ context.Context) string {
+	return "keeps the value from state unless the connection is tested"
+}
+
+func (m keepConnectionTestResult) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m keepConnectionTestResult) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.State.Raw.IsNull() || !req.PlanValue.IsUnknown() {
+		return
+	}
+	var testConnection types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("test_connection"), &testConnection)...)
+	if resp.Diagnostics.HasError() || testConnection.IsUnknown() || testConnection.ValueBool() {
+		return
+	}
+	resp.PlanValue = req.StateValue
+}
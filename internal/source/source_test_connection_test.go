@@ -0,0 +1,21 @@
+package source
+
+import (
+	"testing"
+
+	sailpointBeta "github.com/sailpoint-oss/golang-sdk/v2/api_beta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionTestFailure(t *testing.T) {
+	status := func(value string, details map[string]interface{}) *sailpointBeta.StatusResponse {
+		return &sailpointBeta.StatusResponse{Status: &value, Details: details}
+	}
+
+	assert.Equal(t, "", connectionTestFailure(status("SUCCESS", map[string]interface{}{"useTLS": false})))
+	assert.Equal(t, "Login failed for user 'svc'", connectionTestFailure(status("FAILURE", map[string]interface{}{
+		"error": "Login failed for user 'svc'",
+	})))
+	assert.Equal(t, `status FAILURE: {"code":17}`, connectionTestFailure(status("FAILURE", map[string]interface{}{"code": 17})))
+	assert.Equal(t, "status FAILURE", connectionTestFailure(status("FAILURE", nil)))
+}
@@ -0,0 +1,55 @@
+package source_aggregation
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sailpointBeta "github.com/sailpoint-oss/golang-sdk/v2/api_beta"
+)
+
+type sourceAggregationModel struct {
+	Id                  types.String `tfsdk:"id"`
+	SourceId            types.String `tfsdk:"source_id"`
+	Type                types.String `tfsdk:"type"`
+	File                types.String `tfsdk:"file"`
+	DisableOptimization types.Bool   `tfsdk:"disable_optimization"`
+	Triggers            types.Map    `tfsdk:"triggers"`
+	TimeoutSeconds      types.Int64  `tfsdk:"timeout_seconds"`
+	CompletionStatus    types.String `tfsdk:"completion_status"`
+	Scanned             types.Int64  `tfsdk:"scanned"`
+	Added               types.Int64  `tfsdk:"added"`
+	Changed             types.Int64  `tfsdk:"changed"`
+	Removed             types.Int64  `tfsdk:"removed"`
+}
+
+// Attributes of the task result holding the counts, account and entitlement aggregations use different names.
+var (
+	scannedAttributes = []string{"total"}
+	addedAttributes   = []string{"created", "groupsCreated"}
+	changedAttributes = []string{"updated", "groupsUpdated"}
+	removedAttributes = []string{"deleted", "groupsDeleted"}
+)
+
+func (m *sourceAggregationModel) mapTaskStatus(status *sailpointBeta.TaskStatus) {
+	m.Id = types.StringValue(status.Id)
+	m.CompletionStatus = types.StringPointerValue(status.CompletionStatus.Get())
+	m.Scanned = taskCount(status.Attributes, scannedAttributes)
+	m.Added = taskCount(status.Attributes, addedAttributes)
+	m.Changed = taskCount(status.Attributes, changedAttributes)
+	m.Removed = taskCount(status.Attributes, removedAttributes)
+}
+
+// taskCount returns the first of the named attributes, counts missing in the task result are 0.
+func taskCount(attributes map[string]interface{}, names []string) types.Int64 {
+	for _, name := range names {
+		switch value := attributes[name].(type) {
+		case float64:
+			return types.Int64Value(int64(value))
+		case string:
+			if count, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return types.Int64Value(count)
+			}
+		}
+	}
+	return types.Int64Value(0)
+}
@@ -0,0 +1,348 @@
+package source_aggregation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"terraform-provider-identitynow/internal/util"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v2"
+	sailpointBeta "github.com/sailpoint-oss/golang-sdk/v2/api_beta"
+)
+
+var (
+	_ resource.Resource                   = &sourceAggregationResource{}
+	_ resource.ResourceWithConfigure      = &sourceAggregationResource{}
+	_ resource.ResourceWithValidateConfig = &sourceAggregationResource{}
+)
+
+// Aggregation types, named like the schedule types of the source schedules API.
+const (
+	aggregationTypeAccount = custom.SourceScheduleTypeAccountAggregation
+	aggregationTypeGroup   = custom.SourceScheduleTypeGroupAggregation
+)
+
+// Completion statuses of tasks, other statuses (ERROR, TERMINATED, TEMP_ERROR) fail the aggregation.
+const (
+	completionStatusSuccess = "SUCCESS"
+	completionStatusWarning = "WARNING"
+)
+
+// Implementation of IdentityNow on-demand aggregation - https://developer.sailpoint.com/docs/api/beta/import-accounts
+// and https://developer.sailpoint.com/docs/api/beta/import-entitlements
+func NewSourceAggregationResource() resource.Resource {
+	return &sourceAggregationResource{}
+}
+
+type sourceAggregationResource struct {
+	apiClient *sailpoint.APIClient
+}
+
+func (r *sourceAggregationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*custom.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *sailpoint.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.apiClient = client.ApiClient
+}
+
+func (r *sourceAggregationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_aggregation"
+}
+
+func (r *sourceAggregationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs an aggregation of a source when created and waits for it to complete. The aggregation runs again when any " +
+			"argument except `timeout_seconds` changes, e.g. `triggers`. Destroying the resource does not change the source.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the aggregation task",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_id": schema.StringAttribute{
+				Description: "Source ID",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Aggregation type, " + aggregationTypeAccount + " (default) aggregates accounts and " + aggregationTypeGroup + " entitlements",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(aggregationTypeAccount),
+				Validators: []validator.String{
+					stringvalidator.OneOf(aggregationTypeAccount, aggregationTypeGroup),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"file": schema.StringAttribute{
+				Description: "Path of a local CSV file uploaded for the aggregation of a delimited file source. Changes of the file content " +
+					"are not detected, add e.g. `filesha256(path)` to `triggers` to aggregate again",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"disable_optimization": schema.BoolAttribute{
+				Description: "Reprocess every account whether or not its data has changed. Only supported by " + aggregationTypeAccount,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values, the aggregation runs again when any of them changes",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout_seconds": schema.Int64Attribute{
+				Description: "Longest time to wait for the aggregation to complete, 1800 seconds by default. An aggregation that does not complete in time is saved with its task id as tainted, so it runs again on the next apply",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(1800),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"completion_status": schema.StringAttribute{
+				Description: "Completion status of the aggregation task, SUCCESS or WARNING",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scanned": countAttribute("Number of accounts or entitlements read from the source"),
+			"added":   countAttribute("Number of accounts or entitlements added"),
+			"changed": countAttribute("Number of accounts or entitlements changed"),
+			"removed": countAttribute("Number of accounts or entitlements removed"),
+		},
+	}
+}
+
+func countAttribute(description string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Description: description + ", as reported by the aggregation task",
+		Computed:    true,
+		PlanModifiers: []planmodifier.Int64{
+			int64planmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+func (r *sourceAggregationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config sourceAggregationModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.Type.ValueString() == aggregationTypeGroup && !config.DisableOptimization.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("disable_optimization"),
+			"Invalid Attribute Combination",
+			"disable_optimization is only supported by "+aggregationTypeAccount,
+		)
+	}
+}
+
+func (r *sourceAggregationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan sourceAggregationModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sourceId := plan.SourceId.ValueString()
+	aggregationType := plan.Type.ValueString()
+
+	var file *os.File
+	if !plan.File.IsNull() {
+		var err error
+		file, err = os.Open(plan.File.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("file"),
+				"Error Aggregating Source",
+				"Could not open file of Source '"+sourceId+"': "+err.Error(),
+			)
+			return
+		}
+		defer file.Close()
+	}
+
+	tflog.Info(ctx, "Starting "+aggregationType+" of Source '"+sourceId+"'")
+	taskId, spResp, err := r.startAggregation(ctx, plan, file)
+	if err == nil && taskId == "" {
+		err = fmt.Errorf("no aggregation task was returned")
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Aggregating Source",
+			"Could not start "+aggregationType+" of Source '"+sourceId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+
+	timeout := time.Duration(plan.TimeoutSeconds.ValueInt64()) * time.Second
+	status, spResp, err := util.WaitForTask(ctx, r.apiClient, taskId, timeout)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Aggregating Source",
+			"Could not wait for "+aggregationType+" of Source '"+sourceId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		r.savePartiallyCreated(ctx, &plan, taskId, status, resp)
+		return
+	}
+	completionStatus := *status.CompletionStatus.Get()
+	switch completionStatus {
+	case completionStatusSuccess:
+	case completionStatusWarning:
+		resp.Diagnostics.AddWarning(
+			"Source Aggregation Completed With Warnings",
+			aggregationType+" of Source '"+sourceId+"' completed with warnings:\n"+formatTaskMessages(status.Messages),
+		)
+	default:
+		resp.Diagnostics.AddError(
+			"Error Aggregating Source",
+			aggregationType+" of Source '"+sourceId+"' ended with status '"+completionStatus+"':\n"+formatTaskMessages(status.Messages),
+		)
+		return
+	}
+
+	plan.mapTaskStatus(status)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// savePartiallyCreated keeps an aggregation that did not finish in time in state with the id of its task, so Terraform
+// taints it instead of losing track of it and runs the aggregation again on the next apply.
+func (r *sourceAggregationResource) savePartiallyCreated(ctx context.Context, plan *sourceAggregationModel, taskId string, status *sailpointBeta.TaskStatus, resp *resource.CreateResponse) {
+	plan.Id = types.StringValue(taskId)
+	plan.CompletionStatus = types.StringNull()
+	plan.Scanned = types.Int64Null()
+	plan.Added = types.Int64Null()
+	plan.Changed = types.Int64Null()
+	plan.Removed = types.Int64Null()
+	if status != nil {
+		plan.mapTaskStatus(status)
+	}
+	var diagnostics diag.Diagnostics
+	diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(diagnostics...)
+	if !diagnostics.HasError() {
+		util.AddPartiallyCreatedWarning(&resp.Diagnostics, "Source Aggregation", taskId)
+	}
+}
+
+// startAggregation starts the aggregation and returns the id of its task.
+func (r *sourceAggregationResource) startAggregation(ctx context.Context, plan sourceAggregationModel, file *os.File) (string, *http.Response, error) {
+	sourceId := plan.SourceId.ValueString()
+	if plan.Type.ValueString() == aggregationTypeGroup {
+		request := r.apiClient.Beta.EntitlementsAPI.ImportEntitlements(ctx, sourceId)
+		if file != nil {
+			request = request.CsvFile(file)
+		}
+		task, spResp, err := request.Execute()
+		if err != nil {
+			return "", spResp, err
+		}
+		return task.GetId(), spResp, nil
+	}
+
+	request := r.apiClient.Beta.SourcesAPI.ImportAccounts(ctx, sourceId)
+	if file != nil {
+		request = request.File(file)
+	}
+	if !plan.DisableOptimization.IsNull() {
+		request = request.DisableOptimization(plan.DisableOptimization.ValueBool())
+	}
+	task, spResp, err := request.Execute()
+	if err != nil {
+		return "", spResp, err
+	}
+	return task.Task.GetId(), spResp, nil
+}
+
+// formatTaskMessages lists the messages of a task, one per line.
+func formatTaskMessages(messages []sailpointBeta.TaskStatusMessage) string {
+	if len(messages) == 0 {
+		return "- no messages"
+	}
+	lines := make([]string, 0, len(messages))
+	for _, message := range messages {
+		text := message.LocalizedText.Message
+		if text == "" {
+			text = message.Key
+		}
+		lines = append(lines, "- "+message.Type+": "+text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Read only removes aggregations of deleted sources, task results are not kept by IdentityNow.
+func (r *sourceAggregationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state sourceAggregationModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	_, spResp, err := r.apiClient.V3.SourcesAPI.GetSource(ctx, state.SourceId.ValueString()).Execute()
+	if util.IsNotFound(spResp) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Source Aggregation",
+			"Could not read Source '"+state.SourceId.ValueString()+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+}
+
+// Update only changes `timeout_seconds`, all other arguments replace the resource.
+func (r *sourceAggregationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan sourceAggregationModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete keeps aggregated accounts and entitlements, the aggregation is only removed from state.
+func (r *sourceAggregationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
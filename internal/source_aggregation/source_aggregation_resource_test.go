@@ -0,0 +1,34 @@
+package source_aggregation
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sailpointBeta "github.com/sailpoint-oss/golang-sdk/v2/api_beta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskCount(t *testing.T) {
+	attributes := map[string]interface{}{
+		"total":         float64(120),
+		"created":       "7",
+		"groupsUpdated": float64(3),
+		"deleted":       "not a number",
+	}
+	assert.Equal(t, types.Int64Value(120), taskCount(attributes, scannedAttributes))
+	assert.Equal(t, types.Int64Value(7), taskCount(attributes, addedAttributes))
+	assert.Equal(t, types.Int64Value(3), taskCount(attributes, changedAttributes))
+	assert.Equal(t, types.Int64Value(0), taskCount(attributes, removedAttributes))
+	assert.Equal(t, types.Int64Value(0), taskCount(nil, scannedAttributes))
+}
+
+func TestFormatTaskMessages(t *testing.T) {
+	assert.Equal(t, "- no messages", formatTaskMessages(nil))
+	assert.Equal(t,
+		"- WARN: 2 accounts were skipped\n- ERROR: aggregation.failed",
+		formatTaskMessages([]sailpointBeta.TaskStatusMessage{
+			{Type: "WARN", Key: "aggregation.skipped", LocalizedText: sailpointBeta.LocalizedMessage{Message: "2 accounts were skipped"}},
+			{Type: "ERROR", Key: "aggregation.failed"},
+		}),
+	)
+}
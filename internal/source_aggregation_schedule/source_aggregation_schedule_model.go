@@ -1,11 +1,39 @@
 package source_aggregation_schedule
 
 import (
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"terraform-provider-identitynow/internal/util"
+
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type sourceAggregationScheduleModel struct {
-	SourceCloudId   types.String `tfsdk:"source_cloud_id"`
-	CronExpression  types.String `tfsdk:"cron_expression"`
-	AggregationType types.String `tfsdk:"aggregation_type"`
+	SourceId       types.String             `tfsdk:"source_id"`
+	Type           types.String             `tfsdk:"type"`
+	CronExpression util.CronExpressionValue `tfsdk:"cron_expression"`
+	NextFireTimes  types.List               `tfsdk:"next_fire_times"`
+}
+
+// mapToTerraformModel sets the expression of the schedule, an expression equivalent to the configured one keeps its
+// configured form.
+func (m *sourceAggregationScheduleModel) mapToTerraformModel(schedule *custom.SourceSchedule) {
+	m.Type = types.StringValue(schedule.Type)
+	expression := schedule.CronExpression
+	if !m.CronExpression.IsNull() && !m.CronExpression.IsUnknown() {
+		expression = equivalentExpression(expression, m.CronExpression.ValueString())
+	}
+	m.CronExpression = util.NewCronExpressionValue(expression)
+	m.NextFireTimes = m.CronExpression.NextFireTimes()
+}
+
+// equivalentExpression returns the configured expression when it fires at the same times as the expression, or the expression.
+func equivalentExpression(expression string, configured string) string {
+	parsed, err := util.ParseCronExpression(expression)
+	if err != nil {
+		return expression
+	}
+	if parsedConfigured, err := util.ParseCronExpression(configured); err == nil && parsedConfigured.String() == parsed.String() {
+		return configured
+	}
+	return expression
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -11,20 +12,20 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"net/http"
+	"slices"
+	"strings"
 	"terraform-provider-identitynow/internal/sailpoint/custom"
 	"terraform-provider-identitynow/internal/util"
 )
 
 var (
-	_ resource.Resource              = &sourceAggregationScheduleResource{}
-	_ resource.ResourceWithConfigure = &sourceAggregationScheduleResource{}
-)
-
-const (
-	aggregationTypeAccount     = "account"
-	aggregationTypeEntitlement = "entitlement"
+	_ resource.Resource                 = &sourceAggregationScheduleResource{}
+	_ resource.ResourceWithConfigure    = &sourceAggregationScheduleResource{}
+	_ resource.ResourceWithImportState  = &sourceAggregationScheduleResource{}
+	_ resource.ResourceWithUpgradeState = &sourceAggregationScheduleResource{}
 )
 
+// Implementation of IdentityNow Source Schedules - https://developer.sailpoint.com/docs/api/v2024/source-schedules
 func NewSourceAggregationScheduleResource() resource.Resource {
 	return &sourceAggregationScheduleResource{}
 }
@@ -54,28 +55,34 @@ func (r *sourceAggregationScheduleResource) Metadata(ctx context.Context, req re
 
 func (r *sourceAggregationScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"source_cloud_id": schema.StringAttribute{
-				Description: "Legacy Source ID",
+			"source_id": schema.StringAttribute{
+				Description: "Source ID",
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"cron_expression": schema.StringAttribute{
-				Description: "Cron Expression for the Schedule",
-				Required:    true,
-			},
-			"aggregation_type": schema.StringAttribute{
-				Description: "Aggregation type one of 'account' or 'entitlement'",
+			"type": schema.StringAttribute{
+				Description: "Schedule type, one of " + strings.Join(custom.SourceScheduleTypes, ", ") + ". A source has at most one schedule of each type",
 				Required:    true,
 				Validators: []validator.String{
-					stringvalidator.OneOf(aggregationTypeAccount, aggregationTypeEntitlement),
+					stringvalidator.OneOf(custom.SourceScheduleTypes...),
 				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"cron_expression": schema.StringAttribute{
+				Description: "Quartz Cron Expression for the Schedule, e.g. `0 5 0 * * ?`. The expression is validated during plan and equivalent expressions do not cause a diff",
+				Required:    true,
+				CustomType:  util.CronExpressionType{},
+				Validators: []validator.String{
+					util.QuartzCronExpression(),
+				},
+			},
+			"next_fire_times": util.CronNextFireTimesAttribute(path.Root("cron_expression")),
 		},
 	}
 }
@@ -86,28 +93,28 @@ func (r *sourceAggregationScheduleResource) Create(ctx context.Context, req reso
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	sourceCloudId := plan.SourceCloudId.ValueString()
+	sourceId := plan.SourceId.ValueString()
+	scheduleType := plan.Type.ValueString()
 	cronExpression := plan.CronExpression.ValueString()
-	var spResp *http.Response
-	var err error
-	if plan.AggregationType.ValueString() == aggregationTypeAccount {
-		_, spResp, err = r.apiClient.ModifySourceAccountAggregationSchedule(ctx, sourceCloudId, cronExpression)
-	} else if plan.AggregationType.ValueString() == aggregationTypeEntitlement {
-		_, spResp, err = r.apiClient.ModifySourceEntitlementAggregationSchedule(ctx, sourceCloudId, cronExpression)
-	} else {
-		resp.Diagnostics.AddError(
-			"Invalid Aggregation Type",
-			"Aggregation Type must be either 'account' or 'entitlement'",
-		)
-		return
+
+	// Sources may already have a schedule, e.g. created in the UI, it is taken over like the legacy API did
+	_, spResp, err := r.apiClient.GetSourceSchedule(ctx, sourceId, scheduleType)
+	if err == nil {
+		_, spResp, err = r.apiClient.UpdateSourceScheduleCronExpression(ctx, sourceId, scheduleType, cronExpression)
+	} else if util.IsNotFound(spResp) {
+		_, spResp, err = r.apiClient.CreateSourceSchedule(ctx, sourceId, custom.SourceSchedule{Type: scheduleType, CronExpression: cronExpression})
 	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Source Aggregation Schedule",
-			"Could not create Source Aggregation Schedule : "+err.Error()+"\n"+util.GetBody(spResp),
+			"Could not create "+scheduleType+" schedule of Source '"+sourceId+"': "+err.Error()+"\n"+util.GetBody(spResp),
 		)
 		return
 	}
+	if plan.NextFireTimes.IsUnknown() {
+		plan.NextFireTimes = plan.CronExpression.NextFireTimes()
+	}
+	resp.Diagnostics.Append(util.MarkCreated(ctx, resp.Private)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
@@ -117,38 +124,24 @@ func (r *sourceAggregationScheduleResource) Read(ctx context.Context, req resour
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	sourceCloudId := state.SourceCloudId.ValueString()
-	aggregationType := state.AggregationType.ValueString()
-	var spResp *http.Response
-	var err error
-	var schedule *custom.SourceAggregationSchedule
-	if aggregationType == aggregationTypeAccount {
-		schedule, spResp, err = r.apiClient.ReadSourceAccountAggregationSchedule(ctx, sourceCloudId)
-	} else if aggregationType == aggregationTypeEntitlement {
-		schedule, spResp, err = r.apiClient.ReadSourceEntitlementAggregationSchedule(ctx, sourceCloudId)
-	} else {
-		resp.Diagnostics.AddError(
-			"Invalid Aggregation Type",
-			"Aggregation Type must be either 'account' or 'entitlement'",
-		)
-		return
-	}
-	if spResp.StatusCode == 404 {
+	sourceId := state.SourceId.ValueString()
+	scheduleType := state.Type.ValueString()
+	schedule, spResp, err := util.ReadAfterWrite(ctx, req.Private, func() (*custom.SourceSchedule, *http.Response, error) {
+		return r.apiClient.GetSourceSchedule(ctx, sourceId, scheduleType)
+	})
+	if util.IsNotFound(spResp) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Source Aggregation Schedule",
-			"Could not read Source Aggregation Schedule': "+err.Error()+"\n"+util.GetBody(spResp),
+			"Could not read "+scheduleType+" schedule of Source '"+sourceId+"': "+err.Error()+"\n"+util.GetBody(spResp),
 		)
 		return
 	}
-	if schedule == nil || len(schedule.CronExpressions) == 0 {
-		return
-	}
 
-	state.CronExpression = types.StringValue(schedule.CronExpressions[0])
+	state.mapToTerraformModel(schedule)
 	// Set refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -159,28 +152,20 @@ func (r *sourceAggregationScheduleResource) Update(ctx context.Context, req reso
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	sourceCloudId := plan.SourceCloudId.ValueString()
+	sourceId := plan.SourceId.ValueString()
+	scheduleType := plan.Type.ValueString()
 	cronExpression := plan.CronExpression.ValueString()
-	var spResp *http.Response
-	var err error
-	if plan.AggregationType.ValueString() == aggregationTypeAccount {
-		_, spResp, err = r.apiClient.ModifySourceAccountAggregationSchedule(ctx, sourceCloudId, cronExpression)
-	} else if plan.AggregationType.ValueString() == aggregationTypeEntitlement {
-		_, spResp, err = r.apiClient.ModifySourceEntitlementAggregationSchedule(ctx, sourceCloudId, cronExpression)
-	} else {
-		resp.Diagnostics.AddError(
-			"Invalid Aggregation Type",
-			"Aggregation Type must be either 'account' or 'entitlement'",
-		)
-		return
-	}
+	_, spResp, err := r.apiClient.UpdateSourceScheduleCronExpression(ctx, sourceId, scheduleType, cronExpression)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Source Aggregation Schedule",
-			"Could not update Source Aggregation Schedule : "+err.Error()+"\n"+util.GetBody(spResp),
+			"Could not update "+scheduleType+" schedule of Source '"+sourceId+"': "+err.Error()+"\n"+util.GetBody(spResp),
 		)
 		return
 	}
+	if plan.NextFireTimes.IsUnknown() {
+		plan.NextFireTimes = plan.CronExpression.NextFireTimes()
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
@@ -190,25 +175,65 @@ func (r *sourceAggregationScheduleResource) Delete(ctx context.Context, req reso
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	sourceCloudId := state.SourceCloudId.ValueString()
-	var spResp *http.Response
-	var err error
-	if state.AggregationType.ValueString() == aggregationTypeAccount {
-		spResp, err = r.apiClient.DeleteSourceAccountAggregationSchedule(ctx, sourceCloudId)
-	} else if state.AggregationType.ValueString() == aggregationTypeEntitlement {
-		spResp, err = r.apiClient.DeleteSourceEntitlementAggregationSchedule(ctx, sourceCloudId)
-	} else {
+	sourceId := state.SourceId.ValueString()
+	scheduleType := state.Type.ValueString()
+	spResp, err := r.apiClient.DeleteSourceSchedule(ctx, sourceId, scheduleType)
+	if err != nil && !util.IsNotFound(spResp) {
 		resp.Diagnostics.AddError(
-			"Invalid Aggregation Type",
-			"Aggregation Type must be either 'account' or 'entitlement'",
+			"Error Deleting Source Aggregation Schedule",
+			"Could not delete "+scheduleType+" schedule of Source '"+sourceId+"': "+err.Error()+"\n"+util.GetBody(spResp),
 		)
 		return
 	}
+}
+
+func (r *sourceAggregationScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	sourcePart, scheduleType, err := util.SplitImportId(req.ID, "<sourceId|source:sourceName>/<"+strings.Join(custom.SourceScheduleTypes, "|")+">")
 	if err != nil {
+		resp.Diagnostics.AddError("Error Importing Source Aggregation Schedule", err.Error())
+		return
+	}
+	if !slices.Contains(custom.SourceScheduleTypes, scheduleType) {
 		resp.Diagnostics.AddError(
-			"Error Deleting Source Aggregation Schedule",
-			"Could not delete Source Aggregation Schedule : "+err.Error()+"\n"+util.GetBody(spResp),
+			"Invalid Schedule Type",
+			"Schedule type must be one of "+strings.Join(custom.SourceScheduleTypes, ", ")+", got: "+scheduleType,
 		)
 		return
 	}
+	key := util.ParseImportKey(sourcePart, "source")
+	sourceId := key.Id
+	if key.IsName() {
+		source, spResp, err := util.FindSourceByName(ctx, r.apiClient.ApiClient, key.Name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Source Aggregation Schedule",
+				"Could not find Source '"+key.Name+"': "+err.Error()+"\n"+util.GetBody(spResp),
+			)
+			return
+		}
+		sourceId = *source.Id
+	}
+
+	schedule, spResp, err := r.apiClient.GetSourceSchedule(ctx, sourceId, scheduleType)
+	if util.IsNotFound(spResp) {
+		resp.Diagnostics.AddError(
+			"Error Importing Source Aggregation Schedule",
+			"Source '"+sourcePart+"' has no "+scheduleType+" schedule",
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Source Aggregation Schedule",
+			"Could not read "+scheduleType+" schedule of Source '"+sourcePart+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+
+	state := sourceAggregationScheduleModel{
+		SourceId:       types.StringValue(sourceId),
+		CronExpression: util.NewCronExpressionPointerValue(nil),
+	}
+	state.mapToTerraformModel(schedule)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -0,0 +1,129 @@
+package source_aggregation_schedule
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"terraform-provider-identitynow/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Schedules of version 0 were managed through the legacy `/cc/api` endpoints by the legacy Source ID and the
+// `account` or `entitlement` aggregation type.
+type sourceAggregationScheduleModelV0 struct {
+	SourceCloudId   types.String `tfsdk:"source_cloud_id"`
+	CronExpression  types.String `tfsdk:"cron_expression"`
+	CronExpressions types.Set    `tfsdk:"cron_expressions"`
+	AggregationType types.String `tfsdk:"aggregation_type"`
+	NextFireTimes   types.List   `tfsdk:"next_fire_times"`
+}
+
+// Schedule types replacing the legacy aggregation types.
+var legacyAggregationTypes = map[string]string{
+	"account":     custom.SourceScheduleTypeAccountAggregation,
+	"entitlement": custom.SourceScheduleTypeGroupAggregation,
+}
+
+func (r *sourceAggregationScheduleResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"source_cloud_id": schema.StringAttribute{
+						Required: true,
+					},
+					"cron_expression": schema.StringAttribute{
+						Optional: true,
+					},
+					"cron_expressions": schema.SetAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"aggregation_type": schema.StringAttribute{
+						Required: true,
+					},
+					"next_fire_times": schema.ListAttribute{
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+			StateUpgrader: r.upgradeStateV0,
+		},
+	}
+}
+
+func (r *sourceAggregationScheduleResource) upgradeStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior sourceAggregationScheduleModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	scheduleType, ok := legacyAggregationTypes[prior.AggregationType.ValueString()]
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Error Upgrading Source Aggregation Schedule",
+			"Unknown aggregation type '"+prior.AggregationType.ValueString()+"', expected 'account' or 'entitlement'",
+		)
+		return
+	}
+	sourceCloudId := prior.SourceCloudId.ValueString()
+	source, spResp, err := util.FindSourceByCloudExternalId(ctx, r.apiClient.ApiClient, sourceCloudId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Upgrading Source Aggregation Schedule",
+			"Could not find Source of legacy Source ID '"+sourceCloudId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+
+	state := sourceAggregationScheduleModel{
+		SourceId: types.StringValue(*source.Id),
+		Type:     types.StringValue(scheduleType),
+	}
+	state.upgradeExpressionsV0(ctx, prior, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// upgradeExpressionsV0 keeps the expression of the prior state in `cron_expression`. The source schedules API keeps a
+// single expression per schedule type, so only the configured or else the first of the removed `cron_expressions` is
+// kept and a warning lists the dropped ones.
+func (m *sourceAggregationScheduleModel) upgradeExpressionsV0(ctx context.Context, prior sourceAggregationScheduleModelV0, diagnostics *diag.Diagnostics) {
+	var expressions []string
+	if !prior.CronExpressions.IsNull() {
+		diagnostics.Append(prior.CronExpressions.ElementsAs(ctx, &expressions, false)...)
+		sort.Strings(expressions)
+	}
+	var kept string
+	var dropped []string
+	if !prior.CronExpression.IsNull() {
+		// Extra expressions of a schedule configured with `cron_expression` were reported in `cron_expressions`
+		kept = prior.CronExpression.ValueString()
+		for _, expression := range expressions {
+			if expression != kept {
+				dropped = append(dropped, expression)
+			}
+		}
+	} else if len(expressions) > 0 {
+		kept = expressions[0]
+		dropped = expressions[1:]
+	} else {
+		m.CronExpression = util.NewCronExpressionPointerValue(nil)
+		m.NextFireTimes = types.ListNull(types.StringType)
+		return
+	}
+	if len(dropped) > 0 {
+		diagnostics.AddWarning(
+			"Cron Expressions Dropped",
+			"The source schedules API keeps a single cron expression per schedule type, the "+m.Type.ValueString()+
+				" schedule of Source '"+m.SourceId.ValueString()+"' keeps '"+kept+"' and drops '"+strings.Join(dropped, "', '")+"'",
+		)
+	}
+	m.CronExpression = util.NewCronExpressionValue(kept)
+	m.NextFireTimes = m.CronExpression.NextFireTimes()
+}
@@ -0,0 +1,111 @@
+package source_aggregation_schedule
+
+import (
+	"context"
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"terraform-provider-identitynow/internal/util"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLegacyAggregationTypes(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"account":     custom.SourceScheduleTypeAccountAggregation,
+		"entitlement": custom.SourceScheduleTypeGroupAggregation,
+	}, legacyAggregationTypes)
+	for _, scheduleType := range legacyAggregationTypes {
+		assert.Contains(t, custom.SourceScheduleTypes, scheduleType)
+	}
+}
+
+func TestUpgradeStateV0UnknownAggregationType(t *testing.T) {
+	ctx := context.Background()
+	r := &sourceAggregationScheduleResource{}
+	upgrader := r.UpgradeState(ctx)[0]
+	prior := tfsdk.State{
+		Schema: *upgrader.PriorSchema,
+		Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+	}
+	assert.False(t, prior.Set(ctx, &sourceAggregationScheduleModelV0{
+		SourceCloudId:   types.StringValue("12345"),
+		CronExpression:  types.StringValue("0 5 0 * * ?"),
+		CronExpressions: types.SetNull(types.StringType),
+		AggregationType: types.StringValue("group"),
+		NextFireTimes:   types.ListNull(types.StringType),
+	}).HasError())
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &prior}, &resp)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "Unknown aggregation type 'group'")
+	assert.True(t, resp.State.Raw.IsNull())
+}
+
+func TestUpgradeExpressionsV0(t *testing.T) {
+	ctx := context.Background()
+	expressionSet := func(expressions ...string) types.Set {
+		value, _ := types.SetValueFrom(ctx, types.StringType, expressions)
+		return value
+	}
+	tests := []struct {
+		name           string
+		prior          sourceAggregationScheduleModelV0
+		cronExpression util.CronExpressionValue
+		dropped        string
+	}{
+		{
+			name:           "single expression",
+			prior:          sourceAggregationScheduleModelV0{CronExpression: types.StringValue("0 5 0 * * ?"), CronExpressions: types.SetNull(types.StringType)},
+			cronExpression: util.NewCronExpressionValue("0 5 0 * * ?"),
+		},
+		{
+			name:           "set with one expression",
+			prior:          sourceAggregationScheduleModelV0{CronExpression: types.StringNull(), CronExpressions: expressionSet("0 0 6 ? * MON-FRI")},
+			cronExpression: util.NewCronExpressionValue("0 0 6 ? * MON-FRI"),
+		},
+		{
+			name:           "set with several expressions keeps the first",
+			prior:          sourceAggregationScheduleModelV0{CronExpression: types.StringNull(), CronExpressions: expressionSet("0 0 6 ? * MON-FRI", "0 0 12 ? * SAT")},
+			cronExpression: util.NewCronExpressionValue("0 0 12 ? * SAT"),
+			dropped:        "keeps '0 0 12 ? * SAT' and drops '0 0 6 ? * MON-FRI'",
+		},
+		{
+			name:           "single expression with drift keeps the configured expression",
+			prior:          sourceAggregationScheduleModelV0{CronExpression: types.StringValue("0 5 0 * * ?"), CronExpressions: expressionSet("0 5 0 * * ?", "0 0 12 ? * SAT")},
+			cronExpression: util.NewCronExpressionValue("0 5 0 * * ?"),
+			dropped:        "keeps '0 5 0 * * ?' and drops '0 0 12 ? * SAT'",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := sourceAggregationScheduleModel{
+				SourceId: types.StringValue("2c9180835d2e5168015d32f890ca1581"),
+				Type:     types.StringValue(custom.SourceScheduleTypeAccountAggregation),
+			}
+			var diagnostics diag.Diagnostics
+			state.upgradeExpressionsV0(ctx, test.prior, &diagnostics)
+			assert.False(t, diagnostics.HasError())
+			assert.Equal(t, test.cronExpression, state.CronExpression)
+			assert.Len(t, state.NextFireTimes.Elements(), 5)
+			if test.dropped == "" {
+				assert.Empty(t, diagnostics.Warnings())
+			} else {
+				assert.Len(t, diagnostics.Warnings(), 1)
+				assert.Contains(t, diagnostics.Warnings()[0].Detail(), test.dropped)
+			}
+		})
+	}
+}
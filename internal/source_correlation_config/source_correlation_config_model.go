@@ -0,0 +1,20 @@
+package source_correlation_config
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type sourceCorrelationConfigModel struct {
+	SourceId             types.String               `tfsdk:"source_id"`
+	Id                   types.String               `tfsdk:"id"`
+	Name                 types.String               `tfsdk:"name"`
+	AttributeAssignments []attributeAssignmentModel `tfsdk:"attribute_assignments"`
+}
+
+type attributeAssignmentModel struct {
+	IdentityAttribute types.String `tfsdk:"identity_attribute"`
+	AccountAttribute  types.String `tfsdk:"account_attribute"`
+	Operation         types.String `tfsdk:"operation"`
+	Complex           types.Bool   `tfsdk:"complex"`
+	IgnoreCase        types.Bool   `tfsdk:"ignore_case"`
+	MatchMode         types.String `tfsdk:"match_mode"`
+	FilterString      types.String `tfsdk:"filter_string"`
+}
@@ -0,0 +1,377 @@
+package source_correlation_config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"terraform-provider-identitynow/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &sourceCorrelationConfigResource{}
+	_ resource.ResourceWithConfigure   = &sourceCorrelationConfigResource{}
+	_ resource.ResourceWithImportState = &sourceCorrelationConfigResource{}
+)
+
+// Private state key holding the attribute assignments the Source had before the resource was created or imported, restored on Delete.
+const originalAssignmentsPrivateKey = "original_attribute_assignments"
+
+// Implementation of IdentityNow Source Correlation Configs - https://developer.sailpoint.com/docs/api/v2024/get-correlation-config
+func NewSourceCorrelationConfigResource() resource.Resource {
+	return &sourceCorrelationConfigResource{}
+}
+
+type sourceCorrelationConfigResource struct {
+	apiClient *custom.APIClient
+}
+
+func (r *sourceCorrelationConfigResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*custom.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *sailpoint.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.apiClient = client
+}
+
+func (r *sourceCorrelationConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_correlation_config"
+}
+
+func (r *sourceCorrelationConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Account correlation config of a source. Destroying the resource restores the attribute assignments the source had " +
+			"before the resource was created or imported",
+		Attributes: map[string]schema.Attribute{
+			"source_id": schema.StringAttribute{
+				Description: "The Source id",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "The id of the Correlation Config, referenced by `account_correlation_config` of the Source",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the Correlation Config, set by IdentityNow when not configured",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"attribute_assignments": schema.ListNestedAttribute{
+				Description: "The rules correlating accounts to identities, an account is correlated to the identity matching all of them",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"identity_attribute": schema.StringAttribute{
+							Description: "The identity attribute compared to the account attribute, e.g. 'email'",
+							Required:    true,
+						},
+						"account_attribute": schema.StringAttribute{
+							Description: "The account attribute compared to the identity attribute, e.g. 'mail'",
+							Required:    true,
+						},
+						"operation": schema.StringAttribute{
+							Description: "The operation comparing the attributes, '" + custom.CorrelationOperationEquals + "' by default",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString(custom.CorrelationOperationEquals),
+							Validators: []validator.String{
+								stringvalidator.OneOf(custom.CorrelationOperationEquals),
+							},
+						},
+						"complex": schema.BoolAttribute{
+							Description: "Flag indicating whether or not the account attribute is matched partially, see `match_mode`",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+						},
+						"ignore_case": schema.BoolAttribute{
+							Description: "Flag indicating whether or not the comparison ignores the case",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+						},
+						"match_mode": schema.StringAttribute{
+							Description: "Where the identity attribute is found in the account attribute of complex assignments. One of '" + strings.Join(custom.CorrelationMatchModes, "', '") + "'",
+							Optional:    true,
+							Computed:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(custom.CorrelationMatchModes...),
+							},
+						},
+						"filter_string": schema.StringAttribute{
+							Description: "The filter of the assignment, maintained by IdentityNow when not configured",
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *sourceCorrelationConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan sourceCorrelationConfigModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sourceId := plan.SourceId.ValueString()
+
+	// Every Source has a correlation config, its assignments are kept to restore them on Delete
+	original, spResp, err := r.apiClient.GetSourceCorrelationConfig(ctx, sourceId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Source Correlation Config",
+			"Could not read Correlation Config of Source '"+sourceId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+	resp.Diagnostics.Append(keepOriginalAssignments(ctx, sourceId, original, resp.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := convertToAPIModel(&plan)
+	tflog.Info(ctx, fmt.Sprintf("Replacing Correlation Config: %s", util.PrettyPrint(config)))
+	configResp, spResp, err := r.apiClient.PutSourceCorrelationConfig(ctx, sourceId, config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Source Correlation Config",
+			"Could not update Correlation Config of Source '"+sourceId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+
+	mapToTerraformModel(&plan, configResp)
+	resp.Diagnostics.Append(util.MarkCreated(ctx, resp.Private)...)
+
+	// Set state to fully populated data
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *sourceCorrelationConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state sourceCorrelationConfigModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sourceId := state.SourceId.ValueString()
+	config, spResp, err := util.ReadAfterWrite(ctx, req.Private, func() (*custom.CorrelationConfig, *http.Response, error) {
+		return r.apiClient.GetSourceCorrelationConfig(ctx, sourceId)
+	})
+	if util.IsNotFound(spResp) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Source Correlation Config",
+			"Could not read Correlation Config of Source '"+sourceId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Source Correlation Config: %s", util.PrettyPrint(config)))
+
+	mapToTerraformModel(&state, config)
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *sourceCorrelationConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan sourceCorrelationConfigModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sourceId := plan.SourceId.ValueString()
+
+	config := convertToAPIModel(&plan)
+	tflog.Info(ctx, fmt.Sprintf("Updating Correlation Config: %s", util.PrettyPrint(config)))
+	configResp, spResp, err := r.apiClient.PutSourceCorrelationConfig(ctx, sourceId, config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Source Correlation Config",
+			"Could not update Correlation Config of Source '"+sourceId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+
+	mapToTerraformModel(&plan, configResp)
+
+	// Set state to fully populated data
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete restores the attribute assignments the Source had before the resource was created or imported, the correlation
+// config itself belongs to the Source.
+func (r *sourceCorrelationConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state sourceCorrelationConfigModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sourceId := state.SourceId.ValueString()
+	config := restoredCorrelationConfig(ctx, &state, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Restoring Correlation Config: %s", util.PrettyPrint(config)))
+	_, spResp, err := r.apiClient.PutSourceCorrelationConfig(ctx, sourceId, config)
+	if err != nil && !util.IsNotFound(spResp) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Source Correlation Config",
+			"Could not restore attribute assignments of Correlation Config of Source '"+sourceId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+}
+
+// keepOriginalAssignments stores the attribute assignments of the correlation config in the private state.
+func keepOriginalAssignments(ctx context.Context, sourceId string, original *custom.CorrelationConfig, private util.PrivateStateWriter) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+	originalAssignments, err := json.Marshal(original.AttributeAssignments)
+	if err != nil {
+		diagnostics.AddError(
+			"Error Keeping Source Correlation Config",
+			"Could not keep attribute assignments of Source '"+sourceId+"': "+err.Error(),
+		)
+		return diagnostics
+	}
+	diagnostics.Append(private.SetKey(ctx, originalAssignmentsPrivateKey, originalAssignments)...)
+	return diagnostics
+}
+
+// restoredCorrelationConfig returns the correlation config with the attribute assignments kept in the private state, or
+// without attribute assignments when none were kept.
+func restoredCorrelationConfig(ctx context.Context, state *sourceCorrelationConfigModel, private util.PrivateStateReader, diagnostics *diag.Diagnostics) custom.CorrelationConfig {
+	config := custom.CorrelationConfig{
+		Id:   state.Id.ValueString(),
+		Name: state.Name.ValueString(),
+	}
+	originalAssignments, diags := private.GetKey(ctx, originalAssignmentsPrivateKey)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() || originalAssignments == nil {
+		return config
+	}
+	if err := json.Unmarshal(originalAssignments, &config.AttributeAssignments); err != nil {
+		diagnostics.AddError(
+			"Error Deleting Source Correlation Config",
+			"Could not read the original attribute assignments of Source '"+state.SourceId.ValueString()+"': "+err.Error(),
+		)
+	}
+	return config
+}
+
+func convertToAPIModel(model *sourceCorrelationConfigModel) custom.CorrelationConfig {
+	assignments := make([]custom.CorrelationAttributeMapping, len(model.AttributeAssignments))
+	for i, assignment := range model.AttributeAssignments {
+		assignments[i] = custom.CorrelationAttributeMapping{
+			Property:     assignment.IdentityAttribute.ValueString(),
+			Value:        assignment.AccountAttribute.ValueString(),
+			Operation:    assignment.Operation.ValueString(),
+			Complex:      assignment.Complex.ValueBool(),
+			IgnoreCase:   assignment.IgnoreCase.ValueBool(),
+			MatchMode:    assignment.MatchMode.ValueString(),
+			FilterString: assignment.FilterString.ValueString(),
+		}
+	}
+	return custom.CorrelationConfig{
+		Id:                   model.Id.ValueString(),
+		Name:                 model.Name.ValueString(),
+		AttributeAssignments: assignments,
+	}
+}
+
+func mapToTerraformModel(tfModel *sourceCorrelationConfigModel, config *custom.CorrelationConfig) {
+	tfModel.Id = types.StringValue(config.Id)
+	tfModel.Name = types.StringValue(config.Name)
+	assignments := make([]attributeAssignmentModel, len(config.AttributeAssignments))
+	for i, item := range config.AttributeAssignments {
+		operation := item.Operation
+		if operation == "" {
+			operation = custom.CorrelationOperationEquals
+		}
+		assignments[i] = attributeAssignmentModel{
+			IdentityAttribute: types.StringValue(item.Property),
+			AccountAttribute:  types.StringValue(item.Value),
+			Operation:         types.StringValue(operation),
+			Complex:           types.BoolValue(item.Complex),
+			IgnoreCase:        types.BoolValue(item.IgnoreCase),
+			MatchMode:         stringOrNull(item.MatchMode),
+			FilterString:      stringOrNull(item.FilterString),
+		}
+	}
+	tfModel.AttributeAssignments = assignments
+}
+
+// stringOrNull maps the empty strings returned for fields that are not set to null.
+func stringOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+func (r *sourceCorrelationConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	sourceKey := util.ParseImportKey(req.ID, "source")
+	sourceId := sourceKey.Id
+	if sourceKey.IsName() {
+		source, spResp, err := util.FindSourceByName(ctx, r.apiClient.ApiClient, sourceKey.Name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Source Correlation Config",
+				"Could not find Source '"+sourceKey.Name+"': "+err.Error()+"\n"+util.GetBody(spResp),
+			)
+			return
+		}
+		sourceId = *source.Id
+	}
+
+	// The assignments at import are restored on Delete, like the ones of a created correlation config
+	original, spResp, err := r.apiClient.GetSourceCorrelationConfig(ctx, sourceId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Source Correlation Config",
+			"Could not read Correlation Config of Source '"+sourceId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+	resp.Diagnostics.Append(keepOriginalAssignments(ctx, sourceId, original, resp.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_id"), sourceId)...)
+}
@@ -0,0 +1,101 @@
+package source_correlation_config
+
+import (
+	"context"
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapToTerraformModel(t *testing.T) {
+	var model sourceCorrelationConfigModel
+	mapToTerraformModel(&model, &custom.CorrelationConfig{
+		Id:   "id",
+		Name: "Source Correlation Config",
+		AttributeAssignments: []custom.CorrelationAttributeMapping{
+			{Property: "email", Value: "mail", IgnoreCase: true},
+			{Property: "uid", Value: "sAMAccountName", Operation: "EQ", Complex: true, MatchMode: "START", FilterString: "uid.startsWith(sAMAccountName)"},
+		},
+	})
+	assert.Equal(t, types.StringValue("id"), model.Id)
+	assert.Equal(t, []attributeAssignmentModel{
+		{
+			IdentityAttribute: types.StringValue("email"),
+			AccountAttribute:  types.StringValue("mail"),
+			Operation:         types.StringValue("EQ"),
+			Complex:           types.BoolValue(false),
+			IgnoreCase:        types.BoolValue(true),
+			MatchMode:         types.StringNull(),
+			FilterString:      types.StringNull(),
+		},
+		{
+			IdentityAttribute: types.StringValue("uid"),
+			AccountAttribute:  types.StringValue("sAMAccountName"),
+			Operation:         types.StringValue("EQ"),
+			Complex:           types.BoolValue(true),
+			IgnoreCase:        types.BoolValue(false),
+			MatchMode:         types.StringValue("START"),
+			FilterString:      types.StringValue("uid.startsWith(sAMAccountName)"),
+		},
+	}, model.AttributeAssignments)
+
+	config := convertToAPIModel(&model)
+	assert.Equal(t, "Source Correlation Config", config.Name)
+	assert.Equal(t, custom.CorrelationAttributeMapping{Property: "email", Value: "mail", Operation: "EQ", IgnoreCase: true}, config.AttributeAssignments[0])
+}
+
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p testPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func TestRestoredCorrelationConfig(t *testing.T) {
+	ctx := context.Background()
+	state := sourceCorrelationConfigModel{
+		SourceId: types.StringValue("2c9180835d2e5168015d32f890ca1581"),
+		Id:       types.StringValue("id"),
+		Name:     types.StringValue("Source Correlation Config"),
+		AttributeAssignments: []attributeAssignmentModel{
+			{IdentityAttribute: types.StringValue("uid"), AccountAttribute: types.StringValue("sAMAccountName")},
+		},
+	}
+	original := &custom.CorrelationConfig{
+		Id:   "id",
+		Name: "Source Correlation Config",
+		AttributeAssignments: []custom.CorrelationAttributeMapping{
+			{Property: "email", Value: "mail", Operation: "EQ", IgnoreCase: true},
+		},
+	}
+
+	// Assignments kept on create or import are restored instead of the configured ones
+	private := testPrivateState{}
+	assert.False(t, keepOriginalAssignments(ctx, state.SourceId.ValueString(), original, private).HasError())
+	var diagnostics diag.Diagnostics
+	assert.Equal(t, *original, restoredCorrelationConfig(ctx, &state, private, &diagnostics))
+	assert.False(t, diagnostics.HasError())
+
+	// Sources without assignments have them removed again
+	private = testPrivateState{}
+	assert.False(t, keepOriginalAssignments(ctx, state.SourceId.ValueString(), &custom.CorrelationConfig{}, private).HasError())
+	config := restoredCorrelationConfig(ctx, &state, private, &diagnostics)
+	assert.Equal(t, "id", config.Id)
+	assert.Empty(t, config.AttributeAssignments)
+	assert.False(t, diagnostics.HasError())
+
+	// Without kept assignments, no assignments are restored
+	config = restoredCorrelationConfig(ctx, &state, testPrivateState{}, &diagnostics)
+	assert.Equal(t, custom.CorrelationConfig{Id: "id", Name: "Source Correlation Config"}, config)
+	assert.False(t, diagnostics.HasError())
+
+	restoredCorrelationConfig(ctx, &state, testPrivateState{originalAssignmentsPrivateKey: []byte("{")}, &diagnostics)
+	assert.True(t, diagnostics.HasError())
+}
@@ -0,0 +1,23 @@
+package source_provisioning_policy
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type sourceProvisioningPolicyModel struct {
+	SourceId    types.String `tfsdk:"source_id"`
+	UsageType   types.String `tfsdk:"usage_type"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Fields      []fieldModel `tfsdk:"fields"`
+}
+
+type fieldModel struct {
+	Name          types.String         `tfsdk:"name"`
+	Type          types.String         `tfsdk:"type"`
+	IsRequired    types.Bool           `tfsdk:"is_required"`
+	IsMultiValued types.Bool           `tfsdk:"is_multi_valued"`
+	Transform     jsontypes.Normalized `tfsdk:"transform"`
+	Attributes    jsontypes.Normalized `tfsdk:"attributes"`
+}
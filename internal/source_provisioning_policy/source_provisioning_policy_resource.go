@@ -0,0 +1,360 @@
+package source_provisioning_policy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"terraform-provider-identitynow/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v2"
+	sailpointV3 "github.com/sailpoint-oss/golang-sdk/v2/api_v3"
+)
+
+var (
+	_ resource.Resource                = &sourceProvisioningPolicyResource{}
+	_ resource.ResourceWithConfigure   = &sourceProvisioningPolicyResource{}
+	_ resource.ResourceWithImportState = &sourceProvisioningPolicyResource{}
+)
+
+var usageTypes = func() []string {
+	values := make([]string, len(sailpointV3.AllowedUsageTypeEnumValues))
+	for i, value := range sailpointV3.AllowedUsageTypeEnumValues {
+		values[i] = string(value)
+	}
+	return values
+}()
+
+func NewSourceProvisioningPolicyResource() resource.Resource {
+	return &sourceProvisioningPolicyResource{}
+}
+
+type sourceProvisioningPolicyResource struct {
+	apiClient *sailpoint.APIClient
+}
+
+func (r *sourceProvisioningPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*custom.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *sailpoint.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.apiClient = client.ApiClient
+}
+
+func (r *sourceProvisioningPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_provisioning_policy"
+}
+
+func (r *sourceProvisioningPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"source_id": schema.StringAttribute{
+				Description: "The Source id",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"usage_type": schema.StringAttribute{
+				Description: "The type of provisioning the policy is used for. One of '" + strings.Join(usageTypes, "', '") + "'",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(usageTypes...),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the Provisioning Policy",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the Provisioning Policy",
+				Optional:    true,
+			},
+			"fields": schema.ListNestedAttribute{
+				Description: "The fields of the account provisioned by the policy",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the account attribute",
+							Required:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type of the attribute, 'string' by default",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("string"),
+						},
+						"is_required": schema.BoolAttribute{
+							Description: "Flag indicating whether or not the attribute is required",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+						},
+						"is_multi_valued": schema.BoolAttribute{
+							Description: "Flag indicating whether or not the attribute is multi-valued",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+						},
+						"transform": schema.StringAttribute{
+							Description: "The transform generating the value of the attribute as JSON",
+							CustomType:  jsontypes.NormalizedType{},
+							Optional:    true,
+						},
+						"attributes": schema.StringAttribute{
+							Description: "The attributes of the field as JSON, e.g. the input of an attribute generator or the cloud settings",
+							CustomType:  jsontypes.NormalizedType{},
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *sourceProvisioningPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan sourceProvisioningPolicyModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sourceId := plan.SourceId.ValueString()
+	usageType := sailpointV3.UsageType(plan.UsageType.ValueString())
+	policy := r.convertToAPIModel(&plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Sources come with default policies, an existing policy for the usage type is replaced
+	_, spResp, err := r.apiClient.V3.SourcesAPI.GetProvisioningPolicy(ctx, sourceId, usageType).Execute()
+	if err != nil && !util.IsNotFound(spResp) {
+		resp.Diagnostics.AddError(
+			"Error Creating Source Provisioning Policy",
+			"Error during provisioning policy lookup '"+string(usageType)+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+	var policyResp *sailpointV3.ProvisioningPolicyDto
+	if err == nil {
+		tflog.Info(ctx, fmt.Sprintf("Replacing existing Provisioning Policy: %s", util.PrettyPrint(policy)))
+		policyResp, spResp, err = r.apiClient.V3.SourcesAPI.PutProvisioningPolicy(ctx, sourceId, usageType).ProvisioningPolicyDto(policy).Execute()
+	} else {
+		tflog.Info(ctx, fmt.Sprintf("Creating New Provisioning Policy: %s", util.PrettyPrint(policy)))
+		policyResp, spResp, err = r.apiClient.V3.SourcesAPI.CreateProvisioningPolicy(ctx, sourceId).ProvisioningPolicyDto(policy).Execute()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Source Provisioning Policy",
+			"Could not create Source Provisioning Policy '"+plan.Name.ValueString()+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+
+	r.mapToTerraformModel(&plan, policyResp, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(util.MarkCreated(ctx, resp.Private)...)
+
+	// Set state to fully populated data
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *sourceProvisioningPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state sourceProvisioningPolicyModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sourceId := state.SourceId.ValueString()
+	usageType := sailpointV3.UsageType(state.UsageType.ValueString())
+	policy, spResp, err := util.ReadAfterWrite(ctx, req.Private, func() (*sailpointV3.ProvisioningPolicyDto, *http.Response, error) {
+		return r.apiClient.V3.SourcesAPI.GetProvisioningPolicy(ctx, sourceId, usageType).Execute()
+	})
+	if util.IsNotFound(spResp) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Source Provisioning Policy",
+			"Could not read Source Provisioning Policy '"+string(usageType)+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Source Provisioning Policy: %s", util.PrettyPrint(policy)))
+
+	r.mapToTerraformModel(&state, policy, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *sourceProvisioningPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan sourceProvisioningPolicyModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy := r.convertToAPIModel(&plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Updating Provisioning Policy: %s", util.PrettyPrint(policy)))
+	usageType := sailpointV3.UsageType(plan.UsageType.ValueString())
+	policyResp, spResp, err := r.apiClient.V3.SourcesAPI.PutProvisioningPolicy(ctx, plan.SourceId.ValueString(), usageType).ProvisioningPolicyDto(policy).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Source Provisioning Policy",
+			"Could not update Source Provisioning Policy '"+plan.Name.ValueString()+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+
+	r.mapToTerraformModel(&plan, policyResp, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *sourceProvisioningPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state sourceProvisioningPolicyModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	usageType := sailpointV3.UsageType(state.UsageType.ValueString())
+	spResp, err := r.apiClient.V3.SourcesAPI.DeleteProvisioningPolicy(ctx, state.SourceId.ValueString(), usageType).Execute()
+	if err != nil && !util.IsNotFound(spResp) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Source Provisioning Policy",
+			"Could not delete Source Provisioning Policy '"+state.Name.ValueString()+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+}
+
+func (r *sourceProvisioningPolicyResource) convertToAPIModel(model *sourceProvisioningPolicyModel, diagnostics *diag.Diagnostics) sailpointV3.ProvisioningPolicyDto {
+	usageType, err := sailpointV3.NewUsageTypeFromValue(model.UsageType.ValueString())
+	if err != nil {
+		diagnostics.AddError(
+			"Error Processing Provisioning Policy Usage Type",
+			fmt.Sprintf("Could not process Provisioning Policy Usage Type '%s': %s", model.UsageType.ValueString(), err.Error()),
+		)
+		return sailpointV3.ProvisioningPolicyDto{}
+	}
+	fields := make([]sailpointV3.FieldDetailsDto, len(model.Fields))
+	for i, field := range model.Fields {
+		fields[i] = sailpointV3.FieldDetailsDto{
+			Name:          field.Name.ValueStringPointer(),
+			Type:          field.Type.ValueStringPointer(),
+			IsRequired:    field.IsRequired.ValueBoolPointer(),
+			IsMultiValued: field.IsMultiValued.ValueBoolPointer(),
+			Transform:     util.UnmarshalJsonTypeNormalized(field.Transform, diagnostics),
+			Attributes:    util.UnmarshalJsonTypeNormalized(field.Attributes, diagnostics),
+		}
+	}
+	return sailpointV3.ProvisioningPolicyDto{
+		Name:        model.Name.ValueString(),
+		Description: model.Description.ValueStringPointer(),
+		UsageType:   usageType,
+		Fields:      fields,
+	}
+}
+
+func (r *sourceProvisioningPolicyResource) mapToTerraformModel(tfModel *sourceProvisioningPolicyModel, policy *sailpointV3.ProvisioningPolicyDto, diagnostics *diag.Diagnostics) {
+	tfModel.Name = types.StringValue(policy.Name)
+	tfModel.Description = types.StringPointerValue(policy.Description)
+	if policy.UsageType != nil {
+		tfModel.UsageType = types.StringValue(string(*policy.UsageType))
+	}
+	fields := make([]fieldModel, len(policy.Fields))
+	for i, item := range policy.Fields {
+		fieldType := "string"
+		if item.Type != nil {
+			fieldType = *item.Type
+		}
+		fields[i] = fieldModel{
+			Name:          types.StringPointerValue(item.Name),
+			Type:          types.StringValue(fieldType),
+			IsRequired:    types.BoolValue(item.IsRequired != nil && *item.IsRequired),
+			IsMultiValued: types.BoolValue(item.IsMultiValued != nil && *item.IsMultiValued),
+			Transform:     jsonMapValue(item.Transform, diagnostics),
+			Attributes:    jsonMapValue(item.Attributes, diagnostics),
+		}
+	}
+	tfModel.Fields = fields
+}
+
+// jsonMapValue converts a JSON object of a field, the API returns empty objects for fields configured without them.
+func jsonMapValue(value map[string]interface{}, diagnostics *diag.Diagnostics) jsontypes.Normalized {
+	if len(value) == 0 {
+		return jsontypes.NewNormalizedNull()
+	}
+	return util.MarshalToJsonTypeNormalized(value, diagnostics)
+}
+
+func (r *sourceProvisioningPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	sourcePart, usageType, err := util.SplitImportId(req.ID, "<sourceId|source:sourceName>/<usageType>")
+	if err != nil {
+		resp.Diagnostics.AddError("Error Importing Source Provisioning Policy", err.Error())
+		return
+	}
+	if !sailpointV3.UsageType(usageType).IsValid() {
+		resp.Diagnostics.AddError(
+			"Invalid Usage Type",
+			"Usage type must be one of "+strings.Join(usageTypes, ", ")+", got: "+usageType,
+		)
+		return
+	}
+	sourceKey := util.ParseImportKey(sourcePart, "source")
+	sourceId := sourceKey.Id
+	if sourceKey.IsName() {
+		source, spResp, err := util.FindSourceByName(ctx, r.apiClient, sourceKey.Name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Source Provisioning Policy",
+				"Could not find Source '"+sourceKey.Name+"': "+err.Error()+"\n"+util.GetBody(spResp),
+			)
+			return
+		}
+		sourceId = *source.Id
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_id"), sourceId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("usage_type"), usageType)...)
+}
@@ -0,0 +1,146 @@
+package source_schema
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sailpointV3 "github.com/sailpoint-oss/golang-sdk/v2/api_v3"
+)
+
+// ModifyPlan keeps the computed id and name of attribute schema references from the state. Elements of a set have no
+// path to their prior state, so `UseStateForUnknown` can not do it, attributes are matched by name instead.
+func (r *sourceSchemaResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+	var planSet, stateSet types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("attributes"), &planSet)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("attributes"), &stateSet)...)
+	if resp.Diagnostics.HasError() || planSet.IsUnknown() || planSet.IsNull() {
+		return
+	}
+	var planned, prior []attributeModel
+	var diagnostics diag.Diagnostics
+	diagnostics.Append(planSet.ElementsAs(ctx, &planned, false)...)
+	diagnostics.Append(stateSet.ElementsAs(ctx, &prior, false)...)
+	if diagnostics.HasError() {
+		// Attributes with unknown schema references are only known during apply
+		return
+	}
+	if keepSchemaReferences(planned, prior) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("attributes"), planned)...)
+	}
+}
+
+// keepSchemaReferences copies unknown ids and names of schema references from the prior attribute of the same name, and
+// returns whether any was copied.
+func keepSchemaReferences(planned, prior []attributeModel) bool {
+	changed := false
+	for i := range planned {
+		reference := planned[i].Schema
+		if reference == nil || (!reference.Id.IsUnknown() && !reference.Name.IsUnknown()) {
+			continue
+		}
+		for _, attribute := range prior {
+			if attribute.Name.Equal(planned[i].Name) && attribute.Schema != nil {
+				if reference.Id.IsUnknown() {
+					reference.Id = attribute.Schema.Id
+					changed = true
+				}
+				if reference.Name.IsUnknown() {
+					reference.Name = attribute.Schema.Name
+					changed = true
+				}
+				break
+			}
+		}
+	}
+	return changed
+}
+
+func attributeNames(attributes []attributeModel) []string {
+	names := make([]string, len(attributes))
+	for i, attribute := range attributes {
+		names[i] = attribute.Name.ValueString()
+	}
+	return names
+}
+
+// withExtraAttributes appends the current attributes of the schema that are not configured, except the ones in ignored.
+func withExtraAttributes(attributes, current []sailpointV3.AttributeDefinition, ignored []string) []sailpointV3.AttributeDefinition {
+	names := make([]string, len(attributes))
+	for i, attribute := range attributes {
+		names[i] = attribute.GetName()
+	}
+	for _, attribute := range current {
+		if !slices.Contains(names, attribute.GetName()) && !slices.Contains(ignored, attribute.GetName()) {
+			attributes = append(attributes, attribute)
+		}
+	}
+	return attributes
+}
+
+// withoutExtraAttributes returns the attributes with the given names.
+func withoutExtraAttributes(attributes []sailpointV3.AttributeDefinition, names []string) []sailpointV3.AttributeDefinition {
+	var filtered []sailpointV3.AttributeDefinition
+	for _, attribute := range attributes {
+		if slices.Contains(names, attribute.GetName()) {
+			filtered = append(filtered, attribute)
+		}
+	}
+	return filtered
+}
+
+// uniqueAttributeNames rejects attributes with the same name, the set only removes attributes that are equal in all fields.
+type uniqueAttributeNames struct{}
+
+func (v uniqueAttributeNames) Description(_ context.Context) string {
+	return "attribute names must be unique"
+}
+
+func (v uniqueAttributeNames) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v uniqueAttributeNames) ValidateSet(_ context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if duplicates := duplicateAttributeNames(req.ConfigValue.Elements()); len(duplicates) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Duplicate Schema Attribute",
+			"Attributes are identified by their name, '"+strings.Join(duplicates, "', '")+"' must be defined once",
+		)
+	}
+}
+
+// duplicateAttributeNames returns the known names of more than one attribute, in order of their second occurrence.
+func duplicateAttributeNames(elements []attr.Value) []string {
+	var names, duplicates []string
+	for _, element := range elements {
+		object, ok := element.(types.Object)
+		if !ok {
+			continue
+		}
+		name, ok := object.Attributes()["name"].(types.String)
+		if !ok || name.IsNull() || name.IsUnknown() {
+			continue
+		}
+		if slices.Contains(names, name.ValueString()) {
+			if !slices.Contains(duplicates, name.ValueString()) {
+				duplicates = append(duplicates, name.ValueString())
+			}
+			continue
+		}
+		names = append(names, name.ValueString())
+	}
+	return duplicates
+}
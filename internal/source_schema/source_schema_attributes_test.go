@@ -0,0 +1,73 @@
+package source_schema
+
+import (
+	"terraform-provider-identitynow/internal/util"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sailpointV3 "github.com/sailpoint-oss/golang-sdk/v2/api_v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeepSchemaReferences(t *testing.T) {
+	prior := []attributeModel{
+		{Name: types.StringValue("memberOf"), Schema: util.NewReferenceModel("CONNECTOR_SCHEMA", "id", "group")},
+		{Name: types.StringValue("uid")},
+	}
+	planned := []attributeModel{
+		{Name: types.StringValue("uid")},
+		{Name: types.StringValue("memberOf"), Schema: &util.ReferenceModel{
+			Type: types.StringValue("CONNECTOR_SCHEMA"),
+			Id:   types.StringUnknown(),
+			Name: types.StringValue("group"),
+		}},
+	}
+	assert.True(t, keepSchemaReferences(planned, prior))
+	assert.Equal(t, types.StringValue("id"), planned[1].Schema.Id)
+	assert.Equal(t, types.StringValue("group"), planned[1].Schema.Name)
+	assert.False(t, keepSchemaReferences(planned, prior))
+
+	added := []attributeModel{{Name: types.StringValue("manager"), Schema: &util.ReferenceModel{Id: types.StringUnknown()}}}
+	assert.False(t, keepSchemaReferences(added, prior))
+	assert.True(t, added[0].Schema.Id.IsUnknown())
+}
+
+func TestExtraAttributes(t *testing.T) {
+	definition := func(name string) sailpointV3.AttributeDefinition {
+		return sailpointV3.AttributeDefinition{Name: &name}
+	}
+	names := func(definitions []sailpointV3.AttributeDefinition) []string {
+		result := make([]string, len(definitions))
+		for i, item := range definitions {
+			result[i] = item.GetName()
+		}
+		return result
+	}
+	configured := []sailpointV3.AttributeDefinition{definition("uid"), definition("mail")}
+	current := []sailpointV3.AttributeDefinition{definition("mail"), definition("removed"), definition("added"), definition("uid")}
+
+	assert.Equal(t, []string{"uid", "mail", "added"}, names(withExtraAttributes(configured, current, []string{"removed"})))
+	assert.Equal(t, []string{"uid", "mail", "removed", "added"}, names(withExtraAttributes(configured, current, nil)))
+	assert.Equal(t, []string{"mail", "uid"}, names(withoutExtraAttributes(current, []string{"uid", "mail"})))
+	assert.Empty(t, withoutExtraAttributes(current, nil))
+}
+
+func TestDuplicateAttributeNames(t *testing.T) {
+	attributeTypes := map[string]attr.Type{"name": types.StringType, "type": types.StringType}
+	attribute := func(name types.String, attributeType string) attr.Value {
+		return types.ObjectValueMust(attributeTypes, map[string]attr.Value{"name": name, "type": types.StringValue(attributeType)})
+	}
+	assert.Empty(t, duplicateAttributeNames([]attr.Value{
+		attribute(types.StringValue("uid"), "STRING"),
+		attribute(types.StringValue("memberOf"), "STRING"),
+		attribute(types.StringUnknown(), "STRING"),
+		attribute(types.StringUnknown(), "LONG"),
+	}))
+	assert.Equal(t, []string{"uid"}, duplicateAttributeNames([]attr.Value{
+		attribute(types.StringValue("uid"), "STRING"),
+		attribute(types.StringValue("memberOf"), "STRING"),
+		attribute(types.StringValue("uid"), "LONG"),
+		attribute(types.StringValue("uid"), "INT"),
+	}))
+}
@@ -0,0 +1,212 @@
+package source_schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"terraform-provider-identitynow/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sailpointV3 "github.com/sailpoint-oss/golang-sdk/v2/api_v3"
+)
+
+var (
+	_ datasource.DataSource              = &sourceSchemaDataSource{}
+	_ datasource.DataSourceWithConfigure = &sourceSchemaDataSource{}
+)
+
+const defaultSchemaName = "account"
+
+func NewSourceSchemaDataSource() datasource.DataSource {
+	return &sourceSchemaDataSource{}
+}
+
+type sourceSchemaDataSource struct {
+	apiClient *custom.APIClient
+}
+
+func (d *sourceSchemaDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*custom.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *sailpoint.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.apiClient = client
+}
+
+func (d *sourceSchemaDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_schema"
+}
+
+func (d *sourceSchemaDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A schema of a source as stored in IdentityNow, e.g. discovered by the connector when the source was created, in the " +
+			"shape of the `identitynow_source_schema` resource",
+		Attributes: map[string]schema.Attribute{
+			"source_id": schema.StringAttribute{
+				Description: "The Source id",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: fmt.Sprintf("The name of the Schema. Defaults to '%s'", defaultSchemaName),
+				Optional:    true,
+			},
+			"native_object_type": schema.StringAttribute{
+				Description: "The name of the object type on the native system that the schema represents",
+				Computed:    true,
+			},
+			"identity_attribute": schema.StringAttribute{
+				Description: "The name of the attribute used to calculate the unique identifier for an object in the schema",
+				Computed:    true,
+			},
+			"display_attribute": schema.StringAttribute{
+				Description: "The name of the attribute used to calculate the display value for an object in the schema",
+				Computed:    true,
+			},
+			"hierarchy_attribute": schema.StringAttribute{
+				Description: "The name of the attribute whose values represent other objects in a hierarchy. Only relevant to group schemas",
+				Computed:    true,
+			},
+			"include_permissions": schema.BoolAttribute{
+				Description: "Flag indicating whether or not the include permissions with the object data when aggregating the schema",
+				Computed:    true,
+			},
+			"features": schema.SetAttribute{
+				Description: "The features that the schema supports",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"configuration": schema.StringAttribute{
+				Description: "Holds any extra configuration data that the schema may require",
+				CustomType:  jsontypes.ExactType{},
+				Computed:    true,
+			},
+			"attributes": schema.ListNestedAttribute{
+				Description: "The attribute definitions of the schema",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the attribute",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type of the attribute. One of 'STRING', 'LONG', 'INT', 'BOOLEAN'",
+							Computed:    true,
+						},
+						"schema": schema.SingleNestedAttribute{
+							Description: "A reference to the schema on the source to the attribute values map to",
+							Computed:    true,
+							Attributes: map[string]schema.Attribute{
+								"type": schema.StringAttribute{
+									Computed: true,
+								},
+								"id": schema.StringAttribute{
+									Computed: true,
+								},
+								"name": schema.StringAttribute{
+									Computed: true,
+								},
+							},
+						},
+						"description": schema.StringAttribute{
+							Description: "A human-readable description of the attribute",
+							Computed:    true,
+						},
+						"is_multi": schema.BoolAttribute{
+							Description: "Flag indicating whether or not the attribute is multi-valued",
+							Computed:    true,
+						},
+						"is_entitlement": schema.BoolAttribute{
+							Description: "Flag indicating whether or not the attribute is an entitlement",
+							Computed:    true,
+						},
+						"is_group": schema.BoolAttribute{
+							Description: "Flag indicating whether or not the attribute represents a group",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *sourceSchemaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config sourceSchemaDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sourceId := config.SourceId.ValueString()
+	name := defaultSchemaName
+	if !config.Name.IsNull() {
+		name = config.Name.ValueString()
+	}
+
+	schemas, spResp, err := d.apiClient.ApiClient.V3.SourcesAPI.GetSourceSchemas(ctx, sourceId).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Source Schema",
+			"Could not read schemas of Source '"+sourceId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+	found := findNamedSchema(schemas, name)
+	if found == nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Source Schema",
+			"Source '"+sourceId+"' has no Schema '"+name+"', found: "+strings.Join(schemaNames(schemas), ", "),
+		)
+		return
+	}
+
+	config.Name = types.StringValue(name)
+	config.NativeObjectType = types.StringPointerValue(found.NativeObjectType)
+	config.IdentityAttribute = types.StringPointerValue(found.IdentityAttribute)
+	config.DisplayAttribute = types.StringPointerValue(found.DisplayAttribute)
+	config.HierarchyAttribute = types.StringPointerValue(found.HierarchyAttribute)
+	config.IncludePermissions = types.BoolPointerValue(found.IncludePermissions)
+	config.Features = make([]types.String, len(found.Features))
+	for i, item := range found.Features {
+		config.Features[i] = types.StringValue(item)
+	}
+	config.Configuration = util.MarshalToJsonType(found.Configuration, &resp.Diagnostics)
+	config.Attributes = newAttributeModels(found.Attributes)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// findNamedSchema returns the schema by name, or by native object type for connectors naming their account schema after
+// the object type.
+func findNamedSchema(schemas []sailpointV3.Schema, name string) *sailpointV3.Schema {
+	for i := range schemas {
+		if strings.EqualFold(schemas[i].GetName(), name) {
+			return &schemas[i]
+		}
+	}
+	for i := range schemas {
+		if strings.EqualFold(schemas[i].GetNativeObjectType(), name) {
+			return &schemas[i]
+		}
+	}
+	return nil
+}
+
+func schemaNames(schemas []sailpointV3.Schema) []string {
+	names := make([]string, len(schemas))
+	for i, item := range schemas {
+		names[i] = item.GetName()
+	}
+	return names
+}
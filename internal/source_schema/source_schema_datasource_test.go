@@ -0,0 +1,21 @@
+package source_schema
+
+import (
+	"testing"
+
+	sailpointV3 "github.com/sailpoint-oss/golang-sdk/v2/api_v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNamedSchema(t *testing.T) {
+	name := func(value string) *string { return &value }
+	schemas := []sailpointV3.Schema{
+		{Name: name("account"), NativeObjectType: name("User")},
+		{Name: name("group"), NativeObjectType: name("Group")},
+	}
+	assert.Equal(t, "account", findNamedSchema(schemas, "account").GetName())
+	assert.Equal(t, "group", findNamedSchema(schemas, "Group").GetName())
+	assert.Equal(t, "account", findNamedSchema(schemas, "user").GetName())
+	assert.Nil(t, findNamedSchema(schemas, "role"))
+	assert.Equal(t, []string{"account", "group"}, schemaNames(schemas))
+}
@@ -7,17 +7,18 @@ import (
 )
 
 type sourceSchemaModel struct {
-	Id                 types.String     `tfsdk:"id"`
-	SourceId           types.String     `tfsdk:"source_id"`
-	Name               types.String     `tfsdk:"name"`
-	NativeObjectType   types.String     `tfsdk:"native_object_type"`
-	IdentityAttribute  types.String     `tfsdk:"identity_attribute"`
-	DisplayAttribute   types.String     `tfsdk:"display_attribute"`
-	HierarchyAttribute types.String     `tfsdk:"hierarchy_attribute"`
-	IncludePermissions types.Bool       `tfsdk:"include_permissions"`
-	Features           []types.String   `tfsdk:"features"`
-	Configuration      jsontypes.Exact  `tfsdk:"configuration"`
-	Attributes         []attributeModel `tfsdk:"attributes"`
+	Id                   types.String     `tfsdk:"id"`
+	SourceId             types.String     `tfsdk:"source_id"`
+	Name                 types.String     `tfsdk:"name"`
+	NativeObjectType     types.String     `tfsdk:"native_object_type"`
+	IdentityAttribute    types.String     `tfsdk:"identity_attribute"`
+	DisplayAttribute     types.String     `tfsdk:"display_attribute"`
+	HierarchyAttribute   types.String     `tfsdk:"hierarchy_attribute"`
+	IncludePermissions   types.Bool       `tfsdk:"include_permissions"`
+	Features             []types.String   `tfsdk:"features"`
+	Configuration        jsontypes.Exact  `tfsdk:"configuration"`
+	Attributes           []attributeModel `tfsdk:"attributes"`
+	AllowExtraAttributes types.Bool       `tfsdk:"allow_extra_attributes"`
 }
 
 type attributeModel struct {
@@ -29,3 +30,16 @@ type attributeModel struct {
 	IsEntitlement types.Bool           `tfsdk:"is_entitlement"`
 	IsGroup       types.Bool           `tfsdk:"is_group"`
 }
+
+type sourceSchemaDataSourceModel struct {
+	SourceId           types.String     `tfsdk:"source_id"`
+	Name               types.String     `tfsdk:"name"`
+	NativeObjectType   types.String     `tfsdk:"native_object_type"`
+	IdentityAttribute  types.String     `tfsdk:"identity_attribute"`
+	DisplayAttribute   types.String     `tfsdk:"display_attribute"`
+	HierarchyAttribute types.String     `tfsdk:"hierarchy_attribute"`
+	IncludePermissions types.Bool       `tfsdk:"include_permissions"`
+	Features           []types.String   `tfsdk:"features"`
+	Configuration      jsontypes.Exact  `tfsdk:"configuration"`
+	Attributes         []attributeModel `tfsdk:"attributes"`
+}
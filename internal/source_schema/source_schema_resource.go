@@ -3,15 +3,18 @@ package source_schema
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"terraform-provider-identitynow/internal/sailpoint/custom"
 	"terraform-provider-identitynow/internal/util"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	sailpoint "github.com/sailpoint-oss/golang-sdk/v2"
@@ -19,8 +22,10 @@ import (
 )
 
 var (
-	_ resource.Resource              = &sourceSchemaResource{}
-	_ resource.ResourceWithConfigure = &sourceSchemaResource{}
+	_ resource.Resource                = &sourceSchemaResource{}
+	_ resource.ResourceWithConfigure   = &sourceSchemaResource{}
+	_ resource.ResourceWithImportState = &sourceSchemaResource{}
+	_ resource.ResourceWithModifyPlan  = &sourceSchemaResource{}
 )
 
 func NewSourceSchemaResource() resource.Resource {
@@ -105,9 +110,16 @@ func (r *sourceSchemaResource) Schema(ctx context.Context, req resource.SchemaRe
 				CustomType:  jsontypes.ExactType{},
 				Required:    true,
 			},
-			"attributes": schema.ListNestedAttribute{
-				Description: "The attribute definitions which form the schema",
+			"allow_extra_attributes": schema.BoolAttribute{
+				Description: "Keep attributes that are not configured, e.g. added by IdentityNow during aggregation, instead of removing them from the schema. They are not tracked in the state",
+				Optional:    true,
+			},
+			"attributes": schema.SetNestedAttribute{
+				Description: "The attribute definitions which form the schema, identified by their name",
 				Required:    true,
+				Validators: []validator.Set{
+					uniqueAttributeNames{},
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"name": schema.StringAttribute{
@@ -160,6 +172,9 @@ func (r *sourceSchemaResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 	if existingSchema != nil {
+		if plan.AllowExtraAttributes.ValueBool() {
+			schema.Attributes = withExtraAttributes(schema.Attributes, existingSchema.Attributes, nil)
+		}
 		spResp, err := r.apiClient.V3.SourcesAPI.DeleteSourceSchema(ctx, sourceId, *existingSchema.Id).Execute()
 		if err != nil {
 			resp.Diagnostics.AddError(
@@ -184,6 +199,8 @@ func (r *sourceSchemaResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	resp.Diagnostics.Append(util.MarkCreated(ctx, resp.Private)...)
+
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
@@ -213,8 +230,10 @@ func (r *sourceSchemaResource) Read(ctx context.Context, req resource.ReadReques
 	}
 	sourceId := state.SourceId.ValueString()
 	schemaId := state.Id.ValueString()
-	schema, spResp, err := r.apiClient.V3.SourcesAPI.GetSourceSchema(ctx, sourceId, schemaId).Execute()
-	if spResp.StatusCode == 404 {
+	schema, spResp, err := util.ReadAfterWrite(ctx, req.Private, func() (*sailpointV3.Schema, *http.Response, error) {
+		return r.apiClient.V3.SourcesAPI.GetSourceSchema(ctx, sourceId, schemaId).Execute()
+	})
+	if util.IsNotFound(spResp) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -237,8 +256,9 @@ func (r *sourceSchemaResource) Read(ctx context.Context, req resource.ReadReques
 }
 
 func (r *sourceSchemaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan sourceSchemaModel
+	var plan, state sourceSchemaModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -247,6 +267,18 @@ func (r *sourceSchemaResource) Update(ctx context.Context, req resource.UpdateRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if plan.AllowExtraAttributes.ValueBool() {
+		current, spResp, err := r.apiClient.V3.SourcesAPI.GetSourceSchema(ctx, plan.SourceId.ValueString(), plan.Id.ValueString()).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Source Schema",
+				"Could not read Source Schema '"+plan.Name.ValueString()+"': "+err.Error()+"\n"+util.GetBody(spResp),
+			)
+			return
+		}
+		// Attributes removed from the configuration are not extra attributes
+		schema.Attributes = withExtraAttributes(schema.Attributes, current.Attributes, attributeNames(state.Attributes))
+	}
 	tflog.Info(ctx, fmt.Sprintf("Updating Schema: %s", util.PrettyPrint(schema)))
 	schemaResp, spResp, err := r.apiClient.V3.SourcesAPI.PutSourceSchema(ctx, plan.SourceId.ValueString(), plan.Id.ValueString()).Schema(schema).Execute()
 	if err != nil {
@@ -342,8 +374,17 @@ func (r *sourceSchemaResource) mapToTerraformModel(tfModel *sourceSchemaModel, s
 		tfModel.Features[i] = types.StringValue(item)
 	}
 	tfModel.Configuration = util.MarshalToJsonType(schema.Configuration, diagnostics)
-	attributes := make([]attributeModel, len(schema.Attributes))
-	for i, item := range schema.Attributes {
+	definitions := schema.Attributes
+	if tfModel.AllowExtraAttributes.ValueBool() {
+		definitions = withoutExtraAttributes(definitions, attributeNames(tfModel.Attributes))
+	}
+	tfModel.Attributes = newAttributeModels(definitions)
+}
+
+// newAttributeModels maps the attribute definitions of a schema to the Terraform model.
+func newAttributeModels(definitions []sailpointV3.AttributeDefinition) []attributeModel {
+	attributes := make([]attributeModel, len(definitions))
+	for i, item := range definitions {
 		var attrType string
 		if item.Type != nil {
 			attrType = string(*item.Type)
@@ -362,5 +403,43 @@ func (r *sourceSchemaResource) mapToTerraformModel(tfModel *sourceSchemaModel, s
 			IsGroup:       types.BoolPointerValue(item.IsGroup),
 		}
 	}
-	tfModel.Attributes = attributes
+	return attributes
+}
+
+func (r *sourceSchemaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	sourcePart, schemaPart, err := util.SplitImportId(req.ID, "<sourceId|source:sourceName>/<schemaId|schemaName>")
+	if err != nil {
+		resp.Diagnostics.AddError("Error Importing Source Schema", err.Error())
+		return
+	}
+	sourceKey := util.ParseImportKey(sourcePart, "source")
+	sourceId := sourceKey.Id
+	if sourceKey.IsName() {
+		source, spResp, err := util.FindSourceByName(ctx, r.apiClient, sourceKey.Name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Source Schema",
+				"Could not find Source '"+sourceKey.Name+"': "+err.Error()+"\n"+util.GetBody(spResp),
+			)
+			return
+		}
+		sourceId = *source.Id
+	}
+	schemaId := schemaPart
+	if !util.IsObjectId(schemaPart) {
+		schema := r.findSchema(ctx, sourceId, schemaPart, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if schema == nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Source Schema",
+				"Could not find Source Schema '"+schemaPart+"' on Source '"+sourcePart+"'",
+			)
+			return
+		}
+		schemaId = *schema.Id
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), schemaId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_id"), sourceId)...)
 }
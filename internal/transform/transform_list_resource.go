@@ -0,0 +1,75 @@
+package transform
+
+import (
+	"context"
+	"terraform-provider-identitynow/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v2"
+	sailpoint_v3 "github.com/sailpoint-oss/golang-sdk/v2/api_v3"
+)
+
+// Implementation of IdentityNow Transform listing for `terraform query` - https://developer.sailpoint.com/idn/api/v3/list-transforms
+var (
+	_ list.ListResource              = &transformListResource{}
+	_ list.ListResourceWithConfigure = &transformListResource{}
+)
+
+func NewTransformListResource() list.ListResource {
+	return &transformListResource{}
+}
+
+// transformListResource shares Configure, Metadata and the state mapping with the managed resource.
+type transformListResource struct {
+	transformResource
+}
+
+func (r *transformListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = util.ListFiltersSchema(`name sw "Lookup"`)
+}
+
+func (r *transformListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config util.ListFiltersModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	listRequest := r.apiClient.V3.TransformsAPI.ListTransforms(ctx)
+	if !config.Filters.IsNull() {
+		listRequest = listRequest.Filters(config.Filters.ValueString())
+	}
+	transforms, spResp, err := sailpoint.PaginateWithDefaults[sailpoint_v3.TransformRead](listRequest)
+	if err != nil {
+		diags.AddError(
+			"Error Listing Transforms",
+			"Could not list Transforms: "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	// Internal transforms are provided by IdentityNow and cannot be managed
+	var managed []sailpoint_v3.TransformRead
+	for _, transform := range transforms {
+		if !transform.Internal {
+			managed = append(managed, transform)
+		}
+	}
+
+	stream.Results = util.StreamListResults(ctx, req, managed, func(transform *sailpoint_v3.TransformRead, result *list.ListResult) {
+		result.DisplayName = transform.Name
+		util.SetIdResourceIdentity(ctx, result.Identity, types.StringValue(transform.Id), &result.Diagnostics)
+		if !req.IncludeResource {
+			return
+		}
+		var model transformModel
+		r.mapToTerraformModel(&model, transform, &result.Diagnostics)
+		if result.Diagnostics.HasError() {
+			return
+		}
+		result.Diagnostics.Append(result.Resource.Set(ctx, &model)...)
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	sailpoint "github.com/sailpoint-oss/golang-sdk/v2"
 	sailpoint_v3 "github.com/sailpoint-oss/golang-sdk/v2/api_v3"
+	"net/http"
 	"terraform-provider-identitynow/internal/sailpoint/custom"
 	"terraform-provider-identitynow/internal/util"
 )
@@ -24,6 +25,7 @@ var (
 	_ resource.Resource                = &transformResource{}
 	_ resource.ResourceWithConfigure   = &transformResource{}
 	_ resource.ResourceWithImportState = &transformResource{}
+	_ resource.ResourceWithIdentity    = &transformResource{}
 )
 
 func NewTransformResource() resource.Resource {
@@ -54,6 +56,10 @@ func (r *transformResource) Metadata(ctx context.Context, req resource.MetadataR
 	resp.TypeName = req.ProviderTypeName + "_transform"
 }
 
+func (r *transformResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = util.IdResourceIdentitySchema()
+}
+
 func (r *transformResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
@@ -103,6 +109,10 @@ func (r *transformResource) Create(ctx context.Context, req resource.CreateReque
 
 	plan.Id = types.StringValue(transformResp.Id)
 
+	util.SetIdResourceIdentity(ctx, resp.Identity, plan.Id, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(util.MarkCreated(ctx, resp.Private)...)
+
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
@@ -113,9 +123,12 @@ func (r *transformResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	util.SetIdResourceIdentity(ctx, resp.Identity, state.Id, &resp.Diagnostics)
 
-	transformRead, spResp, err := r.apiClient.V3.TransformsAPI.GetTransform(ctx, state.Id.ValueString()).Execute()
-	if spResp.StatusCode == 404 {
+	transformRead, spResp, err := util.ReadAfterWrite(ctx, req.Private, func() (*sailpoint_v3.TransformRead, *http.Response, error) {
+		return r.apiClient.V3.TransformsAPI.GetTransform(ctx, state.Id.ValueString()).Execute()
+	})
+	if util.IsNotFound(spResp) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -163,6 +176,8 @@ func (r *transformResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	util.SetIdResourceIdentity(ctx, resp.Identity, plan.Id, &resp.Diagnostics)
+
 	// Set refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -206,6 +221,28 @@ func (r *transformResource) mapToTerraformModel(tfModel *transformModel, transfo
 }
 
 func (r *transformResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	key := util.ParseImportKey(util.GetImportId(ctx, req, &resp.Diagnostics), "transform")
+	if !key.IsName() {
+		// Retrieve import ID and save to id attribute
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), key.Id)...)
+		return
+	}
+	transforms, spResp, err := r.apiClient.V3.TransformsAPI.ListTransforms(ctx).Filters(util.FilterEquals("name", key.Name)).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Transform",
+			"Could not find Transform '"+key.Name+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+	for _, transform := range transforms {
+		if transform.Name == key.Name {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), transform.Id)...)
+			return
+		}
+	}
+	resp.Diagnostics.AddError(
+		"Error Importing Transform",
+		"Could not find Transform '"+key.Name+"'",
+	)
 }
@@ -0,0 +1,275 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Number of fire times exposed by `next_fire_times` attributes.
+const cronNextFireTimesCount = 5
+
+var (
+	_ basetypes.StringTypable                    = CronExpressionType{}
+	_ basetypes.StringValuableWithSemanticEquals = CronExpressionValue{}
+	_ xattr.ValidateableAttribute                = CronExpressionValue{}
+)
+
+// CronExpressionType is a string type of cron expressions. Values are validated during plan and expressions firing at
+// the same times are semantically equal, e.g. `0 0 12 ? * MON-FRI` and `0 0 12 ? * 2-6`.
+type CronExpressionType struct {
+	basetypes.StringType
+}
+
+func (t CronExpressionType) String() string {
+	return "util.CronExpressionType"
+}
+
+func (t CronExpressionType) ValueType(_ context.Context) attr.Value {
+	return CronExpressionValue{}
+}
+
+func (t CronExpressionType) Equal(o attr.Type) bool {
+	other, ok := o.(CronExpressionType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t CronExpressionType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return CronExpressionValue{StringValue: in}, nil
+}
+
+func (t CronExpressionType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return CronExpressionValue{StringValue: stringValue}, nil
+}
+
+type CronExpressionValue struct {
+	basetypes.StringValue
+}
+
+func NewCronExpressionValue(value string) CronExpressionValue {
+	return CronExpressionValue{StringValue: types.StringValue(value)}
+}
+
+func NewCronExpressionPointerValue(value *string) CronExpressionValue {
+	return CronExpressionValue{StringValue: types.StringPointerValue(value)}
+}
+
+func (v CronExpressionValue) Type(_ context.Context) attr.Type {
+	return CronExpressionType{}
+}
+
+func (v CronExpressionValue) Equal(o attr.Value) bool {
+	other, ok := o.(CronExpressionValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether both expressions have the same normalized form.
+func (v CronExpressionValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(CronExpressionValue)
+	if !ok {
+		return false, nil
+	}
+	current, err := ParseCronExpression(v.ValueString())
+	if err != nil {
+		return false, nil
+	}
+	updated, err := ParseCronExpression(newValue.ValueString())
+	if err != nil {
+		return false, nil
+	}
+	return current.String() == updated.String(), nil
+}
+
+func (v CronExpressionValue) ValidateAttribute(_ context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+	if problem := validateCronExpression(v.ValueString()); problem != "" {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Cron Expression", problem)
+	}
+}
+
+func validateCronExpression(value string) string {
+	expression, err := ParseCronExpression(value)
+	if err != nil {
+		return "Cron expression '" + value + "' is not valid: " + err.Error()
+	}
+	if _, ok := expression.Next(time.Now().UTC()); !ok {
+		return "Cron expression '" + value + "' never fires"
+	}
+	return ""
+}
+
+// NextFireTimes returns the next fire times of the expression in UTC, or a null list when the expression is not set.
+func (v CronExpressionValue) NextFireTimes() types.List {
+	if v.IsNull() || v.IsUnknown() {
+		return types.ListNull(types.StringType)
+	}
+	return CronNextFireTimes([]string{v.ValueString()})
+}
+
+// CronNextFireTimes returns the next fire times of all expressions in UTC, merged in order. Invalid expressions are skipped.
+func CronNextFireTimes(expressions []string) types.List {
+	now := time.Now().UTC()
+	unique := make(map[time.Time]bool)
+	var fireTimes []time.Time
+	for _, value := range expressions {
+		expression, err := ParseCronExpression(value)
+		if err != nil {
+			continue
+		}
+		for _, fireTime := range expression.NextFireTimes(now, cronNextFireTimesCount) {
+			if !unique[fireTime] {
+				unique[fireTime] = true
+				fireTimes = append(fireTimes, fireTime)
+			}
+		}
+	}
+	sort.Slice(fireTimes, func(i, j int) bool {
+		return fireTimes[i].Before(fireTimes[j])
+	})
+	if len(fireTimes) > cronNextFireTimesCount {
+		fireTimes = fireTimes[:cronNextFireTimesCount]
+	}
+	values := make([]attr.Value, 0, len(fireTimes))
+	for _, fireTime := range fireTimes {
+		values = append(values, types.StringValue(fireTime.Format(time.RFC3339)))
+	}
+	return types.ListValueMust(types.StringType, values)
+}
+
+// QuartzCronExpression rejects standard 5 field expressions, for APIs accepting only the Quartz format.
+func QuartzCronExpression() validator.String {
+	return quartzCronValidator{}
+}
+
+type quartzCronValidator struct{}
+
+func (v quartzCronValidator) Description(_ context.Context) string {
+	return "value must be a Quartz cron expression with 6 or 7 fields"
+}
+
+func (v quartzCronValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v quartzCronValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if len(strings.Fields(req.ConfigValue.ValueString())) == 5 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Cron Expression", v.Description(ctx)+", seconds are required, e.g. '0 0 12 * * ?'")
+	}
+}
+
+// CronNextFireTimesAttribute returns a computed attribute with the next fire times of the cron expressions at
+// expressionPaths. The planned value is kept from state while the expressions do not change, so plans stay empty as
+// time passes.
+func CronNextFireTimesAttribute(expressionPaths ...path.Path) schema.ListAttribute {
+	return schema.ListAttribute{
+		Description: fmt.Sprintf("The next %d fire times of the cron expression, evaluated in UTC. IdentityNow may evaluate the expression in the time zone of the tenant", cronNextFireTimesCount),
+		Computed:    true,
+		ElementType: types.StringType,
+		PlanModifiers: []planmodifier.List{
+			nextFireTimesPlanModifier{expressionPaths: expressionPaths},
+		},
+	}
+}
+
+type nextFireTimesPlanModifier struct {
+	expressionPaths []path.Path
+}
+
+func (m nextFireTimesPlanModifier) Description(_ context.Context) string {
+	return "keeps the fire times from state while the cron expressions do not change"
+}
+
+func (m nextFireTimesPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m nextFireTimesPlanModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	for _, expressionPath := range m.expressionPaths {
+		var planned, current attr.Value
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, expressionPath, &planned)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, expressionPath, &current)...)
+		if resp.Diagnostics.HasError() || !cronValuesEqual(ctx, current, planned) {
+			return
+		}
+	}
+	resp.PlanValue = req.StateValue
+}
+
+// cronValuesEqual compares cron expressions, or sets of them, by their normalized form.
+func cronValuesEqual(ctx context.Context, current, planned attr.Value) bool {
+	if current == nil || planned == nil || planned.IsUnknown() {
+		return false
+	}
+	if current.Equal(planned) {
+		return true
+	}
+	switch plannedValue := planned.(type) {
+	case CronExpressionValue:
+		equal, _ := plannedValue.StringSemanticEquals(ctx, current.(CronExpressionValue))
+		return equal
+	case basetypes.SetValue:
+		currentValue, ok := current.(basetypes.SetValue)
+		if !ok {
+			return false
+		}
+		currentNormalized, currentOk := normalizedCronSet(currentValue)
+		plannedNormalized, plannedOk := normalizedCronSet(plannedValue)
+		return currentOk && plannedOk && currentNormalized == plannedNormalized
+	}
+	return false
+}
+
+func normalizedCronSet(value basetypes.SetValue) (string, bool) {
+	if value.IsNull() || value.IsUnknown() {
+		return "", false
+	}
+	var normalized []string
+	for _, element := range value.Elements() {
+		expression, ok := element.(CronExpressionValue)
+		if !ok || expression.IsNull() || expression.IsUnknown() {
+			return "", false
+		}
+		parsed, err := ParseCronExpression(expression.ValueString())
+		if err != nil {
+			return "", false
+		}
+		normalized = append(normalized, parsed.String())
+	}
+	sort.Strings(normalized)
+	return strings.Join(normalized, "|"), true
+}
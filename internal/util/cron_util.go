@@ -0,0 +1,474 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron expressions in the Quartz format used by IdentityNow - https://www.quartz-scheduler.org/documentation/quartz-2.3.0/tutorials/crontrigger.html
+// Fields are: seconds minutes hours day-of-month month day-of-week [year]. Standard 5 field expressions
+// (minutes hours day-of-month month day-of-week) are accepted as well, they fire at second 0. Standard expressions
+// restricting both day-of-month and day-of-week fire when either one matches, which Quartz can not express, so they
+// are rejected.
+
+type cronRange struct {
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	cronMonthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6, "JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	cronSeconds     = cronRange{min: 0, max: 59}
+	cronMinutes     = cronRange{min: 0, max: 59}
+	cronHours       = cronRange{min: 0, max: 23}
+	cronDaysOfMonth = cronRange{min: 1, max: 31}
+	cronMonths      = cronRange{min: 1, max: 12, names: cronMonthNames}
+	// Quartz numbers days of week from 1 (Sunday) to 7 (Saturday)
+	cronDaysOfWeek = cronRange{min: 1, max: 7, names: map[string]int{
+		"SUN": 1, "MON": 2, "TUE": 3, "WED": 4, "THU": 5, "FRI": 6, "SAT": 7,
+	}}
+	// Standard cron numbers days of week from 0 (Sunday) to 6 (Saturday), 7 is Sunday as well
+	cronStandardDaysOfWeek = cronRange{min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+	cronYears = cronRange{min: 1970, max: 2099}
+)
+
+// CronExpression is a parsed cron expression. Its String form is normalized, expressions which fire at the same times
+// have the same String form.
+type CronExpression struct {
+	seconds     map[int]bool
+	minutes     map[int]bool
+	hours       map[int]bool
+	daysOfMonth map[int]bool
+	months      map[int]bool
+	daysOfWeek  map[int]bool
+	years       map[int]bool
+
+	// Special day of month values: `L`, `L-3`, `LW` and `15W`
+	lastDayOfMonth     bool
+	lastDayOffset      int
+	lastWeekdayOfMonth bool
+	nearestWeekday     int
+	// Special day of week values: `6L` (last Friday) and `6#3` (third Friday)
+	lastDayOfWeek int
+	nthDayOfWeek  int
+	nthWeek       int
+
+	dayOfMonthRestricted bool
+	dayOfWeekRestricted  bool
+}
+
+// ParseCronExpression parses a Quartz or standard cron expression.
+func ParseCronExpression(expression string) (*CronExpression, error) {
+	fields := strings.Fields(expression)
+	standard := len(fields) == 5
+	if standard {
+		fields = append([]string{"0"}, fields...)
+	} else if len(fields) != 6 && len(fields) != 7 {
+		return nil, fmt.Errorf("expected 6 or 7 fields (seconds minutes hours day-of-month month day-of-week [year]), got %d", len(fields))
+	}
+
+	c := &CronExpression{}
+	var err error
+	for _, field := range []struct {
+		name   string
+		value  string
+		values cronRange
+		target *map[int]bool
+	}{
+		{"seconds", fields[0], cronSeconds, &c.seconds},
+		{"minutes", fields[1], cronMinutes, &c.minutes},
+		{"hours", fields[2], cronHours, &c.hours},
+		{"month", fields[4], cronMonths, &c.months},
+	} {
+		if *field.target, err = field.values.parse(field.value); err != nil {
+			return nil, fmt.Errorf("%s: %w", field.name, err)
+		}
+	}
+	if len(fields) == 7 {
+		if c.years, err = cronYears.parse(fields[6]); err != nil {
+			return nil, fmt.Errorf("year: %w", err)
+		}
+	}
+
+	if err = c.parseDayOfMonth(fields[3]); err != nil {
+		return nil, fmt.Errorf("day-of-month: %w", err)
+	}
+	if standard {
+		err = c.parseStandardDayOfWeek(fields[5])
+	} else {
+		err = c.parseDayOfWeek(fields[5])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("day-of-week: %w", err)
+	}
+	if !standard && (fields[3] == "?") == (fields[5] == "?") {
+		return nil, errors.New("exactly one of day-of-month and day-of-week must be '?'")
+	}
+	if c.dayOfMonthRestricted && c.dayOfWeekRestricted {
+		return nil, errors.New("day-of-month and day-of-week can not both be restricted, the expression can not be converted to the Quartz format")
+	}
+	return c, nil
+}
+
+func (c *CronExpression) parseDayOfMonth(field string) error {
+	switch {
+	case field == "?" || field == "*":
+		c.daysOfMonth = cronDaysOfMonth.all()
+		return nil
+	case field == "LW":
+		c.lastWeekdayOfMonth = true
+	case field == "L":
+		c.lastDayOfMonth = true
+	case strings.HasPrefix(field, "L-"):
+		offset, err := strconv.Atoi(field[2:])
+		if err != nil || offset < 0 || offset > 30 {
+			return fmt.Errorf("invalid offset from the last day '%s'", field)
+		}
+		c.lastDayOfMonth, c.lastDayOffset = true, offset
+	case strings.HasSuffix(field, "W"):
+		day, err := cronDaysOfMonth.parseValue(strings.TrimSuffix(field, "W"))
+		if err != nil {
+			return err
+		}
+		c.nearestWeekday = day
+	default:
+		values, err := cronDaysOfMonth.parse(field)
+		if err != nil {
+			return err
+		}
+		c.daysOfMonth = values
+		c.dayOfMonthRestricted = len(values) != len(cronDaysOfMonth.all())
+		return nil
+	}
+	c.dayOfMonthRestricted = true
+	return nil
+}
+
+func (c *CronExpression) parseDayOfWeek(field string) error {
+	switch {
+	case field == "?" || field == "*":
+		c.daysOfWeek = cronDaysOfWeek.all()
+		return nil
+	case field == "L":
+		c.daysOfWeek = map[int]bool{7: true}
+	case strings.HasSuffix(field, "L"):
+		day, err := cronDaysOfWeek.parseValue(strings.TrimSuffix(field, "L"))
+		if err != nil {
+			return err
+		}
+		c.lastDayOfWeek = day
+	case strings.Contains(field, "#"):
+		parts := strings.SplitN(field, "#", 2)
+		day, err := cronDaysOfWeek.parseValue(parts[0])
+		if err != nil {
+			return err
+		}
+		week, err := strconv.Atoi(parts[1])
+		if err != nil || week < 1 || week > 5 {
+			return fmt.Errorf("invalid occurrence in month '%s', expected 1-5", field)
+		}
+		c.nthDayOfWeek, c.nthWeek = day, week
+	default:
+		values, err := cronDaysOfWeek.parse(field)
+		if err != nil {
+			return err
+		}
+		c.daysOfWeek = values
+		c.dayOfWeekRestricted = len(values) != len(cronDaysOfWeek.all())
+		return nil
+	}
+	c.dayOfWeekRestricted = true
+	return nil
+}
+
+func (c *CronExpression) parseStandardDayOfWeek(field string) error {
+	if field == "?" || field == "*" {
+		c.daysOfWeek = cronDaysOfWeek.all()
+		return nil
+	}
+	values, err := cronStandardDaysOfWeek.parse(field)
+	if err != nil {
+		return err
+	}
+	c.daysOfWeek = make(map[int]bool, len(values))
+	for value := range values {
+		c.daysOfWeek[value%7+1] = true
+	}
+	c.dayOfWeekRestricted = len(c.daysOfWeek) != len(cronDaysOfWeek.all())
+	return nil
+}
+
+func (r cronRange) all() map[int]bool {
+	values := make(map[int]bool, r.max-r.min+1)
+	for value := r.min; value <= r.max; value++ {
+		values[value] = true
+	}
+	return values
+}
+
+func (r cronRange) parseValue(value string) (int, error) {
+	if number, ok := r.names[strings.ToUpper(value)]; ok {
+		return number, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value '%s'", value)
+	}
+	if number < r.min || number > r.max {
+		return 0, fmt.Errorf("value %d is out of range %d-%d", number, r.min, r.max)
+	}
+	return number, nil
+}
+
+// parse parses a comma separated list of values (`5`), ranges (`1-5`, `FRI-MON`) and increments (`*/15`, `5/10`, `1-30/2`).
+func (r cronRange) parse(field string) (map[int]bool, error) {
+	values := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		if err := r.parsePart(part, values); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+func (r cronRange) parsePart(part string, values map[int]bool) error {
+	rangePart, step, hasStep := part, 1, false
+	if index := strings.Index(part, "/"); index >= 0 {
+		var err error
+		step, err = strconv.Atoi(part[index+1:])
+		if err != nil || step <= 0 {
+			return fmt.Errorf("invalid increment in '%s'", part)
+		}
+		rangePart, hasStep = part[:index], true
+	}
+
+	start, end := r.min, r.max
+	var err error
+	switch {
+	case rangePart == "*":
+	case strings.Contains(rangePart, "-"):
+		bounds := strings.SplitN(rangePart, "-", 2)
+		if start, err = r.parseValue(bounds[0]); err != nil {
+			return err
+		}
+		if end, err = r.parseValue(bounds[1]); err != nil {
+			return err
+		}
+	default:
+		if start, err = r.parseValue(rangePart); err != nil {
+			return err
+		}
+		if !hasStep {
+			end = start
+		}
+	}
+
+	// Ranges may wrap around, e.g. `FRI-MON` or `22-2`
+	count := end - start + 1
+	if start > end {
+		count += r.max - r.min + 1
+	}
+	for offset := 0; offset < count; offset += step {
+		value := start + offset
+		if value > r.max {
+			value -= r.max - r.min + 1
+		}
+		values[value] = true
+	}
+	return nil
+}
+
+// String returns the normalized Quartz form of the expression.
+func (c *CronExpression) String() string {
+	dayOfMonth, dayOfWeek := "*", "?"
+	switch {
+	case c.dayOfWeekRestricted:
+		dayOfMonth, dayOfWeek = "?", c.dayOfWeekString()
+	case c.dayOfMonthRestricted:
+		dayOfMonth = c.dayOfMonthString()
+	}
+	fields := []string{
+		formatCronValues(c.seconds, cronSeconds),
+		formatCronValues(c.minutes, cronMinutes),
+		formatCronValues(c.hours, cronHours),
+		dayOfMonth,
+		formatCronValues(c.months, cronMonths),
+		dayOfWeek,
+	}
+	if c.years != nil && len(c.years) != len(cronYears.all()) {
+		fields = append(fields, formatCronValues(c.years, cronYears))
+	}
+	return strings.Join(fields, " ")
+}
+
+func (c *CronExpression) dayOfMonthString() string {
+	switch {
+	case c.lastWeekdayOfMonth:
+		return "LW"
+	case c.lastDayOfMonth && c.lastDayOffset > 0:
+		return "L-" + strconv.Itoa(c.lastDayOffset)
+	case c.lastDayOfMonth:
+		return "L"
+	case c.nearestWeekday > 0:
+		return strconv.Itoa(c.nearestWeekday) + "W"
+	}
+	return formatCronValues(c.daysOfMonth, cronDaysOfMonth)
+}
+
+func (c *CronExpression) dayOfWeekString() string {
+	switch {
+	case c.lastDayOfWeek > 0:
+		return strconv.Itoa(c.lastDayOfWeek) + "L"
+	case c.nthDayOfWeek > 0:
+		return strconv.Itoa(c.nthDayOfWeek) + "#" + strconv.Itoa(c.nthWeek)
+	}
+	return formatCronValues(c.daysOfWeek, cronDaysOfWeek)
+}
+
+// formatCronValues formats values as `*` or as a sorted list of values and ranges, e.g. `1-5,10`.
+func formatCronValues(values map[int]bool, r cronRange) string {
+	if len(values) == r.max-r.min+1 {
+		return "*"
+	}
+	sorted := make([]int, 0, len(values))
+	for value := range values {
+		sorted = append(sorted, value)
+	}
+	sort.Ints(sorted)
+
+	var parts []string
+	for i := 0; i < len(sorted); {
+		j := i
+		for j+1 < len(sorted) && sorted[j+1] == sorted[j]+1 {
+			j++
+		}
+		if j > i {
+			parts = append(parts, strconv.Itoa(sorted[i])+"-"+strconv.Itoa(sorted[j]))
+		} else {
+			parts = append(parts, strconv.Itoa(sorted[i]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+// Next returns the first fire time after the given time, in its location. False is returned when the expression
+// never fires again, e.g. `0 0 0 30 2 ?`.
+func (c *CronExpression) Next(after time.Time) (time.Time, bool) {
+	location := after.Location()
+	t := after.Truncate(time.Second).Add(time.Second)
+	for t.Year() <= cronYears.max {
+		year, month, day := t.Date()
+		switch {
+		case c.years != nil && !c.years[year]:
+			t = time.Date(year+1, time.January, 1, 0, 0, 0, 0, location)
+		case !c.months[int(month)]:
+			t = time.Date(year, month+1, 1, 0, 0, 0, 0, location)
+		case !c.matchesDay(t):
+			t = time.Date(year, month, day+1, 0, 0, 0, 0, location)
+		default:
+			if hour, minute, second, ok := c.nextTimeOfDay(t.Hour(), t.Minute(), t.Second()); ok {
+				return time.Date(year, month, day, hour, minute, second, 0, location), true
+			}
+			t = time.Date(year, month, day+1, 0, 0, 0, 0, location)
+		}
+	}
+	return time.Time{}, false
+}
+
+// NextFireTimes returns up to count fire times after the given time.
+func (c *CronExpression) NextFireTimes(after time.Time, count int) []time.Time {
+	var times []time.Time
+	for len(times) < count {
+		next, ok := c.Next(after)
+		if !ok {
+			break
+		}
+		times = append(times, next)
+		after = next
+	}
+	return times
+}
+
+func (c *CronExpression) nextTimeOfDay(fromHour, fromMinute, fromSecond int) (int, int, int, bool) {
+	for hour := fromHour; hour <= cronHours.max; hour++ {
+		if !c.hours[hour] {
+			continue
+		}
+		minute := 0
+		if hour == fromHour {
+			minute = fromMinute
+		}
+		for ; minute <= cronMinutes.max; minute++ {
+			if !c.minutes[minute] {
+				continue
+			}
+			second := 0
+			if hour == fromHour && minute == fromMinute {
+				second = fromSecond
+			}
+			for ; second <= cronSeconds.max; second++ {
+				if c.seconds[second] {
+					return hour, minute, second, true
+				}
+			}
+		}
+	}
+	return 0, 0, 0, false
+}
+
+func (c *CronExpression) matchesDay(t time.Time) bool {
+	return c.matchesDayOfMonth(t) && c.matchesDayOfWeek(t)
+}
+
+func (c *CronExpression) matchesDayOfMonth(t time.Time) bool {
+	day := t.Day()
+	lastDay := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	switch {
+	case c.lastWeekdayOfMonth:
+		return day == nearestWeekday(t, lastDay, lastDay)
+	case c.lastDayOfMonth:
+		return day == lastDay-c.lastDayOffset
+	case c.nearestWeekday > 0:
+		return c.nearestWeekday <= lastDay && day == nearestWeekday(t, c.nearestWeekday, lastDay)
+	}
+	return c.daysOfMonth[day]
+}
+
+// nearestWeekday returns the weekday nearest to the day within the month of t.
+func nearestWeekday(t time.Time, day int, lastDay int) int {
+	switch time.Date(t.Year(), t.Month(), day, 0, 0, 0, 0, t.Location()).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		if day == lastDay {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+func (c *CronExpression) matchesDayOfWeek(t time.Time) bool {
+	dayOfWeek := int(t.Weekday()) + 1
+	switch {
+	case c.lastDayOfWeek > 0:
+		lastDay := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+		return dayOfWeek == c.lastDayOfWeek && t.Day()+7 > lastDay
+	case c.nthDayOfWeek > 0:
+		return dayOfWeek == c.nthDayOfWeek && (t.Day()-1)/7+1 == c.nthWeek
+	}
+	return c.daysOfWeek[dayOfWeek]
+}
@@ -0,0 +1,94 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCronExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		normalized string
+		err        string
+	}{
+		{expression: "0 5 0 * * ?", normalized: "0 5 0 * * ?"},
+		{expression: "0 5 0 ? * *", normalized: "0 5 0 * * ?"},
+		{expression: "0 0 12 ? * MON-FRI", normalized: "0 0 12 ? * 2-6"},
+		{expression: "0 0 12 ? * 2,3,4,5,6", normalized: "0 0 12 ? * 2-6"},
+		{expression: "0 0/15 * * * ?", normalized: "0 0,15,30,45 * * * ?"},
+		{expression: "0 */15 * * * ? *", normalized: "0 0,15,30,45 * * * ?"},
+		{expression: "0 0 22-2 * * ?", normalized: "0 0 0-2,22-23 * * ?"},
+		{expression: "0 0 8 L-2 JAN,JUL ? 2030", normalized: "0 0 8 L-2 1,7 ? 2030"},
+		{expression: "0 0 8 15W * ?", normalized: "0 0 8 15W * ?"},
+		{expression: "0 0 8 ? * 6#3", normalized: "0 0 8 ? * 6#3"},
+		{expression: "0 0 8 ? * FRIL", normalized: "0 0 8 ? * 6L"},
+		{expression: "30 9 * * 1-5", normalized: "0 30 9 ? * 2-6"},
+		{expression: "0 0 1 * *", normalized: "0 0 0 1 * ?"},
+		{expression: "0 0 * * 0", normalized: "0 0 0 ? * 1"},
+		{expression: "0 0 1 * 0", err: "day-of-month and day-of-week can not both be restricted"},
+		{expression: "0 12 * *", err: "expected 6 or 7 fields"},
+		{expression: "0 0 12 * * *", err: "exactly one of day-of-month and day-of-week must be '?'"},
+		{expression: "0 0 24 * * ?", err: "hours: value 24 is out of range 0-23"},
+		{expression: "0 0 12 ? FOO *", err: "month: invalid value 'FOO'"},
+		{expression: "0 0/0 12 * * ?", err: "minutes: invalid increment in '0/0'"},
+		{expression: "0 0 12 ? * 2#6", err: "day-of-week: invalid occurrence in month '2#6', expected 1-5"},
+	}
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			expression, err := ParseCronExpression(test.expression)
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.normalized, expression.String())
+			// The normalized form is a valid Quartz expression with the same normalized form
+			roundTrip, err := ParseCronExpression(expression.String())
+			assert.NoError(t, err)
+			if assert.NotNil(t, roundTrip) {
+				assert.Equal(t, test.normalized, roundTrip.String())
+			}
+		})
+	}
+}
+
+func TestCronExpressionNextFireTimes(t *testing.T) {
+	// Friday
+	after := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	format := func(times []time.Time) []string {
+		var result []string
+		for _, fireTime := range times {
+			result = append(result, fireTime.Format(time.RFC3339))
+		}
+		return result
+	}
+	tests := []struct {
+		expression string
+		times      []string
+	}{
+		{"0 5 0 * * ?", []string{"2024-03-02T00:05:00Z", "2024-03-03T00:05:00Z"}},
+		{"0 0 12 ? * MON-FRI", []string{"2024-03-01T12:00:00Z", "2024-03-04T12:00:00Z"}},
+		{"0 0 9 L * ?", []string{"2024-03-31T09:00:00Z", "2024-04-30T09:00:00Z"}},
+		// 1st of June 2024 is a Saturday, the nearest weekday within the month is Monday the 3rd
+		{"0 0 9 1W * ?", []string{"2024-04-01T09:00:00Z", "2024-05-01T09:00:00Z", "2024-06-03T09:00:00Z"}},
+		{"0 0 9 LW * ?", []string{"2024-03-29T09:00:00Z", "2024-04-30T09:00:00Z"}},
+		{"0 0 9 ? * 6#3", []string{"2024-03-15T09:00:00Z", "2024-04-19T09:00:00Z"}},
+		{"0 0 9 ? * 6L", []string{"2024-03-29T09:00:00Z", "2024-04-26T09:00:00Z"}},
+		{"0 0 0 29 2 ?", []string{"2028-02-29T00:00:00Z", "2032-02-29T00:00:00Z"}},
+		{"0 0 0 30 2 ?", nil},
+		{"0 12 * * 1", []string{"2024-03-04T12:00:00Z", "2024-03-11T12:00:00Z"}},
+	}
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			expression, err := ParseCronExpression(test.expression)
+			assert.NoError(t, err)
+			count := len(test.times)
+			if count == 0 {
+				count = 1
+			}
+			assert.Equal(t, test.times, format(expression.NextFireTimes(after, count)))
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package util
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// IdResourceIdentitySchema is the identity of resources identified by the IdentityNow object id.
+func IdResourceIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "The id of the object",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// SetIdResourceIdentity stores the object id into the resource identity, when Terraform supports resource identities.
+func SetIdResourceIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id types.String, diagnostics *diag.Diagnostics) {
+	if identity == nil || id.IsNull() || id.IsUnknown() {
+		return
+	}
+	diagnostics.Append(identity.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// GetImportId returns the import identifier. When a resource is imported by its identity, the id of the identity is returned.
+func GetImportId(ctx context.Context, req resource.ImportStateRequest, diagnostics *diag.Diagnostics) string {
+	if req.ID != "" || req.Identity == nil {
+		return req.ID
+	}
+	var id types.String
+	diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root("id"), &id)...)
+	return id.ValueString()
+}
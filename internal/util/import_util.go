@@ -0,0 +1,58 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var objectIdPattern = regexp.MustCompile(`^([0-9a-fA-F]{32}|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})$`)
+
+// ImportKey is a single part of an import identifier, which references an object either by id or by name.
+type ImportKey struct {
+	Id   string
+	Name string
+}
+
+func (k ImportKey) IsName() bool {
+	return k.Name != ""
+}
+
+func (k ImportKey) String() string {
+	if k.IsName() {
+		return k.Name
+	}
+	return k.Id
+}
+
+// IsObjectId reports whether value looks like an IdentityNow object id (32 hex characters or a UUID).
+func IsObjectId(value string) bool {
+	return objectIdPattern.MatchString(value)
+}
+
+// ParseImportKey parses "<prefix>:<name>", an object id or a plain name.
+func ParseImportKey(value, prefix string) ImportKey {
+	value = strings.TrimSpace(value)
+	if name, found := strings.CutPrefix(value, prefix+":"); found {
+		return ImportKey{Name: name}
+	}
+	if IsObjectId(value) {
+		return ImportKey{Id: value}
+	}
+	return ImportKey{Name: value}
+}
+
+// SplitImportId splits a composite import identifier "<parent>/<child>". The child is taken after the last slash,
+// so the parent part may itself contain slashes (e.g. a Source name).
+func SplitImportId(value, format string) (string, string, error) {
+	index := strings.LastIndex(value, "/")
+	if index <= 0 || index == len(value)-1 {
+		return "", "", fmt.Errorf("unexpected import identifier '%s', expected format '%s'", value, format)
+	}
+	return value[:index], value[index+1:], nil
+}
+
+// FilterEquals builds a SCIM-like "eq" filter expression for the List APIs.
+func FilterEquals(field, value string) string {
+	return field + " eq \"" + strings.ReplaceAll(value, "\"", "\\\"") + "\""
+}
@@ -0,0 +1,107 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImportKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		prefix string
+		want   ImportKey
+	}{
+		{
+			name:   "IdWithoutDashes",
+			value:  "2c9180835d191a86015d28455b4a2329",
+			prefix: "source",
+			want:   ImportKey{Id: "2c9180835d191a86015d28455b4a2329"},
+		},
+		{
+			name:   "Uuid",
+			value:  "d201c5e9-d37b-4aff-af14-66414f39d569",
+			prefix: "workflow",
+			want:   ImportKey{Id: "d201c5e9-d37b-4aff-af14-66414f39d569"},
+		},
+		{
+			name:   "PrefixedName",
+			value:  "source:Active Directory",
+			prefix: "source",
+			want:   ImportKey{Name: "Active Directory"},
+		},
+		{
+			name:   "PrefixedNameLookingLikeId",
+			value:  "source:2c9180835d191a86015d28455b4a2329",
+			prefix: "source",
+			want:   ImportKey{Name: "2c9180835d191a86015d28455b4a2329"},
+		},
+		{
+			name:   "PlainName",
+			value:  "Employees",
+			prefix: "identity_profile",
+			want:   ImportKey{Name: "Employees"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseImportKey(tt.value, tt.prefix))
+		})
+	}
+}
+
+func TestSplitImportId(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		wantParent string
+		wantChild  string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "Simple",
+			value:      "Employees/active",
+			wantParent: "Employees",
+			wantChild:  "active",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "ParentWithSlash",
+			value:      "source:HR/Payroll/account",
+			wantParent: "source:HR/Payroll",
+			wantChild:  "account",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:    "MissingSeparator",
+			value:   "Employees",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "MissingChild",
+			value:   "Employees/",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "MissingParent",
+			value:   "/active",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent, child, err := SplitImportId(tt.value, "<parent>/<child>")
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.wantParent, parent)
+			assert.Equal(t, tt.wantChild, child)
+		})
+	}
+}
+
+func TestFilterEquals(t *testing.T) {
+	assert.Equal(t, `name eq "Active Directory"`, FilterEquals("name", "Active Directory"))
+	assert.Equal(t, `name eq "a \"quoted\" name"`, FilterEquals("name", `a "quoted" name`))
+}
@@ -0,0 +1,44 @@
+package util
+
+import (
+	"context"
+	"iter"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ListFiltersModel is the configuration of list resources supporting IdentityNow filter expressions.
+type ListFiltersModel struct {
+	Filters types.String `tfsdk:"filters"`
+}
+
+// ListFiltersSchema is the configuration schema of list resources supporting IdentityNow filter expressions.
+func ListFiltersSchema(example string) schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"filters": schema.StringAttribute{
+				Description: "Filter expression applied by IdentityNow, e.g. `" + example + "`",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// StreamListResults pushes one result per item, stopping at the limit requested by Terraform.
+// The mapper sets the identity and, when requested, the resource of the result.
+func StreamListResults[T any](ctx context.Context, req list.ListRequest, items []T, mapper func(item *T, result *list.ListResult)) iter.Seq[list.ListResult] {
+	return func(push func(list.ListResult) bool) {
+		for i := range items {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+			result := req.NewListResult(ctx)
+			mapper(&items[i], &result)
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamListResults(t *testing.T) {
+	ctx := context.Background()
+	req := list.ListRequest{
+		Limit:                  2,
+		ResourceSchema:         schema.Schema{},
+		ResourceIdentitySchema: IdResourceIdentitySchema(),
+	}
+	items := []string{"a", "b", "c"}
+
+	var ids []string
+	for result := range StreamListResults(ctx, req, items, func(item *string, result *list.ListResult) {
+		result.DisplayName = *item
+		SetIdResourceIdentity(ctx, result.Identity, types.StringValue(*item), &result.Diagnostics)
+	}) {
+		assert.False(t, result.Diagnostics.HasError())
+		var id types.String
+		result.Identity.GetAttribute(ctx, path.Root("id"), &id)
+		assert.Equal(t, result.DisplayName, id.ValueString())
+		ids = append(ids, id.ValueString())
+	}
+	assert.Equal(t, []string{"a", "b"}, ids)
+}
@@ -0,0 +1,43 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotCompleted is wrapped by the error PollUntil returns when the timeout elapses.
+var ErrNotCompleted = errors.New("not completed")
+
+// PollUntil calls poll every interval until it reports done or returns an error. When timeout elapses first, also during
+// a poll, the value of the last poll is returned with an error wrapping ErrNotCompleted, so callers can report the last
+// known state. When ctx is cancelled, its error is returned unchanged.
+func PollUntil[T any](ctx context.Context, interval time.Duration, timeout time.Duration, poll func(ctx context.Context) (T, bool, error)) (T, error) {
+	pollCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	stopped := func() error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w within %s", ErrNotCompleted, timeout)
+	}
+	for {
+		value, done, err := poll(pollCtx)
+		if done && err == nil {
+			return value, nil
+		}
+		// Polls interrupted by the timeout or a cancellation fail with the error of the context
+		if pollCtx.Err() != nil {
+			return value, stopped()
+		}
+		if err != nil {
+			return value, err
+		}
+		select {
+		case <-pollCtx.Done():
+			return value, stopped()
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPollUntil(t *testing.T) {
+	ctx := context.Background()
+
+	calls := 0
+	value, err := PollUntil(ctx, time.Millisecond, time.Second, func(ctx context.Context) (int, bool, error) {
+		calls++
+		return calls, calls == 3, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, value)
+
+	// Errors stop polling
+	calls = 0
+	_, err = PollUntil(ctx, time.Millisecond, time.Second, func(ctx context.Context) (int, bool, error) {
+		calls++
+		return calls, false, errors.New("failed")
+	})
+	assert.EqualError(t, err, "failed")
+	assert.Equal(t, 1, calls)
+
+	// The last value is returned on timeout
+	value, err = PollUntil(ctx, time.Millisecond, 20*time.Millisecond, func(ctx context.Context) (int, bool, error) {
+		return 42, false, nil
+	})
+	assert.EqualError(t, err, "not completed within 20ms")
+	assert.ErrorIs(t, err, ErrNotCompleted)
+	assert.Equal(t, 42, value)
+
+	// A timeout during a poll, e.g. while waiting for a response, is reported as timeout as well
+	value, err = PollUntil(ctx, time.Millisecond, 20*time.Millisecond, func(ctx context.Context) (int, bool, error) {
+		<-ctx.Done()
+		return 7, false, ctx.Err()
+	})
+	assert.EqualError(t, err, "not completed within 20ms")
+	assert.Equal(t, 7, value)
+
+	// Cancelling the parent context returns its error unchanged
+	cancelled, cancel := context.WithCancel(ctx)
+	_, err = PollUntil(cancelled, time.Millisecond, time.Second, func(ctx context.Context) (int, bool, error) {
+		cancel()
+		return 0, false, nil
+	})
+	assert.Equal(t, context.Canceled, err)
+
+	cancelled, cancel = context.WithCancel(ctx)
+	_, err = PollUntil(cancelled, time.Millisecond, time.Second, func(ctx context.Context) (int, bool, error) {
+		cancel()
+		return 0, false, ctx.Err()
+	})
+	assert.Equal(t, context.Canceled, err)
+
+	// A parent deadline before the timeout is not reported as timeout
+	expiring, cancelExpiring := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancelExpiring()
+	_, err = PollUntil(expiring, time.Millisecond, time.Second, func(ctx context.Context) (int, bool, error) {
+		return 0, false, nil
+	})
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
@@ -0,0 +1,93 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Private state key holding the time the object was created by the provider.
+const createdAtPrivateKey = "created_at"
+
+// Private state key marking an object kept in state after a failed Create.
+const partiallyCreatedPrivateKey = "partially_created"
+
+// IdentityNow may return 404 for a short time after an object was created.
+const readAfterWriteWindow = 5 * time.Minute
+
+var readAfterWriteDelays = []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}
+
+// PrivateStateReader is implemented by the private state of Read requests.
+type PrivateStateReader interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// PrivateStateWriter is implemented by the private state of Create responses.
+type PrivateStateWriter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// IsNotFound reports whether IdentityNow responded with 404. It is safe to call with a nil response (e.g. transport errors).
+func IsNotFound(resp *http.Response) bool {
+	return resp != nil && resp.StatusCode == http.StatusNotFound
+}
+
+// MarkCreated records the creation time into the private state, so the following Read tolerates eventual consistency.
+func MarkCreated(ctx context.Context, private PrivateStateWriter) diag.Diagnostics {
+	value, _ := json.Marshal(time.Now().UTC().Format(time.RFC3339))
+	return private.SetKey(ctx, createdAtPrivateKey, value)
+}
+
+// createdRecently reports whether the object was created by the provider within readAfterWriteWindow.
+func createdRecently(ctx context.Context, private PrivateStateReader) bool {
+	value, diags := private.GetKey(ctx, createdAtPrivateKey)
+	if diags.HasError() || value == nil {
+		return false
+	}
+	var createdAt string
+	if err := json.Unmarshal(value, &createdAt); err != nil {
+		return false
+	}
+	created, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return false
+	}
+	return time.Since(created) < readAfterWriteWindow
+}
+
+// MarkPartiallyCreated records into the private state that the configuration of the object did not complete.
+func MarkPartiallyCreated(ctx context.Context, private PrivateStateWriter) diag.Diagnostics {
+	return private.SetKey(ctx, partiallyCreatedPrivateKey, []byte("true"))
+}
+
+// IsPartiallyCreated reports whether the object was kept in state after a failed Create.
+func IsPartiallyCreated(ctx context.Context, private PrivateStateReader) bool {
+	value, diags := private.GetKey(ctx, partiallyCreatedPrivateKey)
+	return !diags.HasError() && string(value) == "true"
+}
+
+// ReadAfterWrite executes the read. When the object is not found shortly after it was created, the read is retried
+// a few times with a growing delay before the 404 is returned to the caller.
+func ReadAfterWrite[T any](ctx context.Context, private PrivateStateReader, read func() (T, *http.Response, error)) (T, *http.Response, error) {
+	result, resp, err := read()
+	if !IsNotFound(resp) || !createdRecently(ctx, private) {
+		return result, resp, err
+	}
+	for _, delay := range readAfterWriteDelays {
+		tflog.Debug(ctx, "Object not found shortly after it was created, retrying", map[string]interface{}{"delay": delay.String()})
+		select {
+		case <-ctx.Done():
+			return result, resp, err
+		case <-time.After(delay):
+		}
+		result, resp, err = read()
+		if !IsNotFound(resp) {
+			return result, resp, err
+		}
+	}
+	return result, resp, err
+}
@@ -0,0 +1,76 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p testPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func TestPartiallyCreated(t *testing.T) {
+	ctx := context.Background()
+	private := testPrivateState{}
+	assert.False(t, IsPartiallyCreated(ctx, private))
+	assert.False(t, MarkPartiallyCreated(ctx, private).HasError())
+	assert.True(t, IsPartiallyCreated(ctx, private))
+}
+
+func TestReadAfterWrite(t *testing.T) {
+	ctx := context.Background()
+	delays := readAfterWriteDelays
+	t.Cleanup(func() { readAfterWriteDelays = delays })
+	readAfterWriteDelays = []time.Duration{time.Millisecond, time.Millisecond}
+
+	notFoundThenFound := func(notFoundCount int) func() (string, *http.Response, error) {
+		calls := 0
+		return func() (string, *http.Response, error) {
+			calls++
+			if calls <= notFoundCount {
+				return "", &http.Response{StatusCode: http.StatusNotFound}, errors.New("404 Not Found")
+			}
+			return "found", &http.Response{StatusCode: http.StatusOK}, nil
+		}
+	}
+
+	created := testPrivateState{}
+	assert.False(t, MarkCreated(ctx, created).HasError())
+
+	result, resp, err := ReadAfterWrite(ctx, created, notFoundThenFound(2))
+	assert.NoError(t, err)
+	assert.Equal(t, "found", result)
+	assert.False(t, IsNotFound(resp))
+
+	// Retries are bounded
+	_, resp, err = ReadAfterWrite(ctx, created, notFoundThenFound(3))
+	assert.Error(t, err)
+	assert.True(t, IsNotFound(resp))
+
+	// Objects not created recently are not retried, so drift is detected immediately
+	_, resp, _ = ReadAfterWrite(ctx, testPrivateState{}, notFoundThenFound(1))
+	assert.True(t, IsNotFound(resp))
+
+	old := testPrivateState{createdAtPrivateKey: []byte(`"2020-01-01T00:00:00Z"`)}
+	_, resp, _ = ReadAfterWrite(ctx, old, notFoundThenFound(1))
+	assert.True(t, IsNotFound(resp))
+}
+
+func TestIsNotFound(t *testing.T) {
+	assert.False(t, IsNotFound(nil))
+	assert.False(t, IsNotFound(&http.Response{StatusCode: http.StatusOK}))
+	assert.True(t, IsNotFound(&http.Response{StatusCode: http.StatusNotFound}))
+}
@@ -3,25 +3,89 @@ package util
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	sailpoint "github.com/sailpoint-oss/golang-sdk/v2"
+	sailpointBeta "github.com/sailpoint-oss/golang-sdk/v2/api_beta"
+	sailpointV3 "github.com/sailpoint-oss/golang-sdk/v2/api_v3"
 )
 
+// Interval between reads of a task status.
+var taskPollInterval = time.Second
+
 func WaitUntilCompletedOrFailAfter(ctx context.Context, apiClient *sailpoint.APIClient, taskId string, maxWaitTimeSec int64) error {
-	timeoutTime := time.Now().Unix() + maxWaitTimeSec
-	for {
-		var lastResponse string
+	lastResponse, err := PollUntil(ctx, taskPollInterval, time.Duration(maxWaitTimeSec)*time.Second, func(ctx context.Context) (string, bool, error) {
 		status, _, _ := apiClient.Beta.TaskManagementAPI.GetTaskStatus(ctx, taskId).Execute()
-		if status != nil {
-			lastResponse = PrettyPrint(status)
-			if status.CompletionStatus.IsSet() && status.CompletionStatus.Get() != nil && *status.CompletionStatus.Get() == "SUCCESS" {
-				return nil
-			}
+		if status == nil {
+			return "", false, nil
+		}
+		completionStatus := status.CompletionStatus.Get()
+		return PrettyPrint(status), completionStatus != nil && *completionStatus == "SUCCESS", nil
+	})
+	if err != nil {
+		return fmt.Errorf("task did not complete within %d seconds. Last response %s", maxWaitTimeSec, lastResponse)
+	}
+	return nil
+}
+
+// WaitForTask waits until the task has a completion status, e.g. SUCCESS, WARNING or ERROR, and returns its final status.
+func WaitForTask(ctx context.Context, apiClient *sailpoint.APIClient, taskId string, timeout time.Duration) (*sailpointBeta.TaskStatus, *http.Response, error) {
+	var lastResponse *http.Response
+	status, err := PollUntil(ctx, taskPollInterval, timeout, func(ctx context.Context) (*sailpointBeta.TaskStatus, bool, error) {
+		status, spResp, err := apiClient.Beta.TaskManagementAPI.GetTaskStatus(ctx, taskId).Execute()
+		lastResponse = spResp
+		// Tasks may not be readable right after they were launched
+		if IsNotFound(spResp) {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return status, status.CompletionStatus.Get() != nil, nil
+	})
+	if err != nil {
+		return status, lastResponse, fmt.Errorf("task '%s': %w", taskId, err)
+	}
+	return status, lastResponse, nil
+}
+
+func FindSourceByName(ctx context.Context, apiClient *sailpoint.APIClient, name string) (*sailpointV3.Source, *http.Response, error) {
+	sources, spResp, err := apiClient.V3.SourcesAPI.ListSources(ctx).Filters(FilterEquals("name", name)).Execute()
+	if err != nil {
+		return nil, spResp, err
+	}
+	for _, source := range sources {
+		if source.Name == name {
+			return &source, spResp, nil
+		}
+	}
+	return nil, spResp, fmt.Errorf("source with name '%s' not found", name)
+}
+
+// FindSourceByCloudExternalId finds the source of a legacy Source ID. The legacy ID cannot be filtered on, so all sources are listed.
+func FindSourceByCloudExternalId(ctx context.Context, apiClient *sailpoint.APIClient, cloudExternalId string) (*sailpointV3.Source, *http.Response, error) {
+	sources, spResp, err := sailpoint.PaginateWithDefaults[sailpointV3.Source](apiClient.V3.SourcesAPI.ListSources(ctx))
+	if err != nil {
+		return nil, spResp, err
+	}
+	for _, source := range sources {
+		if value, ok := source.ConnectorAttributes["cloudExternalId"]; ok && fmt.Sprint(value) == cloudExternalId {
+			return &source, spResp, nil
 		}
-		time.Sleep(1 * time.Second)
-		if time.Now().Unix() > timeoutTime {
-			return fmt.Errorf("task did not complete within %d seconds. Last response %s", maxWaitTimeSec, lastResponse)
+	}
+	return nil, spResp, fmt.Errorf("source with legacy Source ID '%s' not found", cloudExternalId)
+}
+
+func FindIdentityProfileByName(ctx context.Context, apiClient *sailpoint.APIClient, name string) (*sailpointBeta.IdentityProfile, *http.Response, error) {
+	identityProfiles, spResp, err := apiClient.Beta.IdentityProfilesAPI.ListIdentityProfiles(ctx).Filters(FilterEquals("name", name)).Execute()
+	if err != nil {
+		return nil, spResp, err
+	}
+	for _, identityProfile := range identityProfiles {
+		if identityProfile.Name == name {
+			return &identityProfile, spResp, nil
 		}
 	}
+	return nil, spResp, fmt.Errorf("identity profile with name '%s' not found", name)
 }
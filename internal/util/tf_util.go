@@ -1,6 +1,7 @@
 package util
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"reflect"
 	"strings"
@@ -34,3 +35,13 @@ func GetTFStringPointer(value types.String) *string {
 	}
 	return value.ValueStringPointer()
 }
+
+// AddPartiallyCreatedWarning explains that an object created by a failed multi-step Create is kept in state.
+// Terraform marks such an object as tainted, so it is replaced by the next apply or removed by destroy.
+func AddPartiallyCreatedWarning(diagnostics *diag.Diagnostics, objectType string, id string) {
+	diagnostics.AddWarning(
+		objectType+" Partially Created",
+		objectType+" '"+id+"' was created, but its configuration did not complete. It is saved in state as tainted, "+
+			"so Terraform replaces it on the next apply or removes it on destroy.",
+	)
+}
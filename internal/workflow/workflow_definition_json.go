@@ -0,0 +1,218 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sort"
+	"strings"
+	"terraform-provider-identitynow/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sailpointBeta "github.com/sailpoint-oss/golang-sdk/v2/api_beta"
+)
+
+// Step keys of UI exports only used by the visual builder to lay out the steps.
+var uiStepKeys = []string{"position"}
+
+// Trigger attribute keys of UI exports, as named in the IdentityNow API, which `trigger.attributes` can hold.
+var exportTriggerAttributeKeys = []string{"id", "filter.$", "attributeToFilter", "name", "description", "cronString"}
+
+// workflowExport is the part of a workflow exported from the UI which is managed by `definition_json`. Other keys of the
+// export (id, name, owner, creator, created, modified, ...) are ignored, they are managed by the other attributes or IdentityNow.
+type workflowExport struct {
+	Definition *exportDefinition `json:"definition,omitempty"`
+	Trigger    *exportTrigger    `json:"trigger,omitempty"`
+}
+
+type exportDefinition struct {
+	Start string                 `json:"start"`
+	Steps map[string]interface{} `json:"steps"`
+}
+
+type exportTrigger struct {
+	Type       string                 `json:"type"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// parseDefinitionJson reads the UI export and normalizes it, so it can be compared with the workflow read from IdentityNow.
+func parseDefinitionJson(value jsontypes.Normalized, diagnostics *diag.Diagnostics) *workflowExport {
+	var export workflowExport
+	if diags := value.Unmarshal(&export); diags.HasError() {
+		diagnostics.Append(diags...)
+		return nil
+	}
+	if export.Definition != nil {
+		removeUiStepKeys(export.Definition.Steps)
+	}
+	if export.Trigger != nil {
+		// Attributes the provider can not send would be dropped silently, so they are rejected
+		if unsupported := unsupportedTriggerAttributeKeys(export.Trigger.Attributes); len(unsupported) > 0 {
+			diagnostics.AddError(
+				"Unsupported Workflow Trigger Attributes",
+				"The trigger attributes '"+strings.Join(unsupported, "', '")+"' of the export are not supported, remove them from "+
+					"`definition_json`. Supported trigger attributes are '"+strings.Join(exportTriggerAttributeKeys, "', '")+"'",
+			)
+			return nil
+		}
+		export.Trigger.Attributes = triggerAttributesToMap(triggerAttributesFromMap(export.Trigger.Attributes))
+	}
+	return &export
+}
+
+// unsupportedTriggerAttributeKeys returns the sorted keys of the attributes that are not in exportTriggerAttributeKeys.
+func unsupportedTriggerAttributeKeys(attributes map[string]interface{}) []string {
+	var unsupported []string
+	for key := range attributes {
+		if !slices.Contains(exportTriggerAttributeKeys, key) {
+			unsupported = append(unsupported, key)
+		}
+	}
+	sort.Strings(unsupported)
+	return unsupported
+}
+
+func removeUiStepKeys(steps map[string]interface{}) {
+	for _, step := range steps {
+		stepAttributes, ok := step.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range uiStepKeys {
+			delete(stepAttributes, key)
+		}
+		// Nested steps of loop operators
+		if loopAttributes, ok := stepAttributes["attributes"].(map[string]interface{}); ok {
+			if loopSteps, ok := loopAttributes["steps"].(map[string]interface{}); ok {
+				removeUiStepKeys(loopSteps)
+			}
+		}
+	}
+}
+
+// triggerAttributesFromMap reads trigger attributes of the export, keyed as in the IdentityNow API.
+func triggerAttributesFromMap(attributes map[string]interface{}) triggerAttributes {
+	get := func(key string) types.String {
+		if value, ok := attributes[key].(string); ok {
+			return types.StringValue(value)
+		}
+		return types.StringNull()
+	}
+	return triggerAttributes{
+		Id:                get("id"),
+		Filter:            get("filter.$"),
+		AttributeToFilter: get("attributeToFilter"),
+		Name:              get("name"),
+		Description:       get("description"),
+		CronString:        util.CronExpressionValue{StringValue: get("cronString")},
+	}
+}
+
+func triggerAttributesToMap(attributes triggerAttributes) map[string]interface{} {
+	result := make(map[string]interface{})
+	for key, value := range map[string]types.String{
+		"id":                attributes.Id,
+		"filter.$":          attributes.Filter,
+		"attributeToFilter": attributes.AttributeToFilter,
+		"name":              attributes.Name,
+		"description":       attributes.Description,
+		"cronString":        attributes.CronString.StringValue,
+	} {
+		if value.ValueString() != "" {
+			result[key] = value.ValueString()
+		}
+	}
+	return result
+}
+
+// convertFromWorkflowExport converts the export to the definition and trigger of the API model.
+func (r *workflowResource) convertFromWorkflowExport(export *workflowExport) (*sailpointBeta.WorkflowDefinition, *sailpointBeta.WorkflowTrigger) {
+	var modelDef *sailpointBeta.WorkflowDefinition
+	if export.Definition != nil {
+		modelDef = &sailpointBeta.WorkflowDefinition{
+			Start: sailpointBeta.PtrString(export.Definition.Start),
+			Steps: export.Definition.Steps,
+		}
+	}
+	var wfTrigger *sailpointBeta.WorkflowTrigger
+	if export.Trigger != nil {
+		wfTrigger = &sailpointBeta.WorkflowTrigger{
+			Type:       export.Trigger.Type,
+			Attributes: r.convertFromTriggerAttributes(triggerAttributesFromMap(export.Trigger.Attributes)),
+		}
+	}
+	return modelDef, wfTrigger
+}
+
+// mapToDefinitionJson keeps the configured export while it matches the workflow, so UI-only keys do not cause a diff.
+// Otherwise, the normalized definition and trigger of the workflow are returned.
+func (r *workflowResource) mapToDefinitionJson(workflow *sailpointBeta.Workflow, configured jsontypes.Normalized, diagnostics *diag.Diagnostics) jsontypes.Normalized {
+	var actual workflowExport
+	if workflow.Definition != nil {
+		actual.Definition = &exportDefinition{
+			Start: workflow.Definition.GetStart(),
+			Steps: workflow.Definition.Steps,
+		}
+	}
+	if workflow.Trigger != nil && workflow.Trigger.Type != "" {
+		actual.Trigger = &exportTrigger{
+			Type:       workflow.Trigger.Type,
+			Attributes: triggerAttributesToMap(r.convertToTriggerAttributes(workflow.Trigger.Type, workflow.Trigger.Attributes)),
+		}
+	}
+	actualJson, err := json.Marshal(actual)
+	if err != nil {
+		diagnostics.AddError("Error Mapping Workflow", "Could not convert Workflow to JSON: "+err.Error())
+		return configured
+	}
+
+	var configuredDiagnostics diag.Diagnostics
+	if expected := parseDefinitionJson(configured, &configuredDiagnostics); expected != nil {
+		expectedJson, err := json.Marshal(expected)
+		if err == nil && string(expectedJson) == string(actualJson) {
+			return configured
+		}
+	}
+	return jsontypes.NewNormalizedValue(string(actualJson))
+}
+
+var _ validator.String = definitionJsonValidator{}
+
+// definitionJsonValidator checks the graph of steps of the export like definitionValidator does for `definition`.
+type definitionJsonValidator struct{}
+
+func (v definitionJsonValidator) Description(_ context.Context) string {
+	return "the export contains a definition with a valid graph of steps"
+}
+
+func (v definitionJsonValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v definitionJsonValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	export := parseDefinitionJson(jsontypes.NewNormalizedValue(req.ConfigValue.ValueString()), &resp.Diagnostics)
+	if export == nil {
+		return
+	}
+	if export.Definition == nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Workflow Definition", "The export must contain a definition")
+		return
+	}
+	for _, problem := range validateWorkflowSteps(export.Definition.Start, export.Definition.Steps) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Workflow Definition", problem)
+	}
+}
+
+// definitionJsonOrNull returns the export of the model, or nil when `definition_json` is not used.
+func definitionJsonOrNull(model *workflowModel, diagnostics *diag.Diagnostics) *workflowExport {
+	if model.DefinitionJson.IsNull() || model.DefinitionJson.IsUnknown() {
+		return nil
+	}
+	return parseDefinitionJson(model.DefinitionJson, diagnostics)
+}
@@ -0,0 +1,55 @@
+package workflow
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDefinitionJson(t *testing.T) {
+	var diagnostics diag.Diagnostics
+	export := parseDefinitionJson(jsontypes.NewNormalizedValue(`{
+		"id": "d201c5e9-7f29-4a5b-a0a5-6b3bd1e4e1c5",
+		"name": "Send Email",
+		"creator": {"type": "IDENTITY", "id": "2c9180844d5d1a5b014d5f70c0f40001"},
+		"created": "2024-10-01T12:00:00Z",
+		"modified": "2024-10-02T12:00:00Z",
+		"enabled": true,
+		"definition": {
+			"start": "Send Email",
+			"steps": {
+				"Send Email": {"actionId": "sp:send-email", "nextStep": "End", "type": "action", "position": {"x": 10, "y": 20}},
+				"End": {"type": "success", "position": {"x": 10, "y": 120}}
+			}
+		},
+		"trigger": {"type": "EVENT", "attributes": {"id": "idn:identity-created", "filter.$": "$.attributes", "description": ""}}
+	}`), &diagnostics)
+	assert.False(t, diagnostics.HasError())
+
+	normalized, err := json.Marshal(export)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"definition": {
+			"start": "Send Email",
+			"steps": {
+				"Send Email": {"actionId": "sp:send-email", "nextStep": "End", "type": "action"},
+				"End": {"type": "success"}
+			}
+		},
+		"trigger": {"type": "EVENT", "attributes": {"id": "idn:identity-created", "filter.$": "$.attributes"}}
+	}`, string(normalized))
+}
+
+func TestParseDefinitionJsonUnsupportedTriggerAttributes(t *testing.T) {
+	var diagnostics diag.Diagnostics
+	export := parseDefinitionJson(jsontypes.NewNormalizedValue(`{
+		"definition": {"start": "End", "steps": {"End": {"type": "success"}}},
+		"trigger": {"type": "SCHEDULED", "attributes": {"cronString": "0 0 12 * * ?", "timeZone": "Europe/Berlin", "frequency": "daily"}}
+	}`), &diagnostics)
+	assert.Nil(t, export)
+	assert.True(t, diagnostics.HasError())
+	assert.Contains(t, diagnostics.Errors()[0].Detail(), "The trigger attributes 'frequency', 'timeZone' of the export are not supported")
+}
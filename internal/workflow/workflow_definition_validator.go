@@ -0,0 +1,329 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const (
+	stepTypeSuccess = "success"
+	stepTypeFailure = "failure"
+	stepTypeChoice  = "choice"
+	// Loop operator runs its own nested steps, it is the only step allowed to be part of a cycle
+	loopActionId = "sp:loop:iterator"
+)
+
+var _ validator.Object = definitionValidator{}
+
+// definitionValidator checks the graph of workflow steps at plan time, so broken definitions are not sent to IdentityNow.
+type definitionValidator struct{}
+
+func (v definitionValidator) Description(_ context.Context) string {
+	return "start step exists, all step references resolve, every path ends in a success or failure step, no step is orphaned and cycles only go through loop operators"
+}
+
+func (v definitionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v definitionValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	var value definition
+	resp.Diagnostics.Append(req.ConfigValue.As(ctx, &value, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Values known only after apply cannot be validated
+	if value.Start.IsUnknown() || value.Steps.IsNull() || value.Steps.IsUnknown() {
+		return
+	}
+
+	var steps map[string]interface{}
+	if diags := value.Steps.Unmarshal(&steps); diags.HasError() {
+		resp.Diagnostics.AddAttributeError(
+			req.Path.AtName("steps"),
+			"Invalid Workflow Definition",
+			"Steps must be a JSON object of steps keyed by the step name",
+		)
+		return
+	}
+	for _, problem := range validateWorkflowSteps(value.Start.ValueString(), steps) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Workflow Definition", problem)
+	}
+}
+
+// workflowStep is a step of the workflow graph with its resolved references to the following steps.
+type workflowStep struct {
+	name    string
+	end     bool
+	loop    bool
+	targets []string
+}
+
+// validateWorkflowSteps returns all problems found in the graph of steps. Nested steps of loop operators are validated as well.
+func validateWorkflowSteps(start string, steps map[string]interface{}) []string {
+	return validateStepGraph("", start, steps)
+}
+
+func validateStepGraph(scope string, start string, steps map[string]interface{}) []string {
+	var problems []string
+	addProblem := func(format string, args ...interface{}) {
+		problems = append(problems, scope+fmt.Sprintf(format, args...))
+	}
+
+	names := make([]string, 0, len(steps))
+	for name := range steps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	graph := make(map[string]*workflowStep, len(steps))
+	for _, name := range names {
+		attributes, ok := steps[name].(map[string]interface{})
+		if !ok {
+			addProblem("step '%s' must be a JSON object", name)
+			continue
+		}
+		step := parseWorkflowStep(name, attributes)
+		graph[name] = step
+		if step.loop {
+			problems = append(problems, validateLoopSteps(scope, name, attributes)...)
+		}
+	}
+
+	// References of each step resolve to existing steps
+	for _, name := range names {
+		step, ok := graph[name]
+		if !ok {
+			continue
+		}
+		var resolved []string
+		for _, target := range step.targets {
+			if _, exists := steps[target]; exists {
+				resolved = append(resolved, target)
+			} else {
+				addProblem("step '%s' references step '%s' which does not exist", name, target)
+			}
+		}
+		if !step.end && len(step.targets) == 0 {
+			addProblem("step '%s' has no next step, every path must end in a '%s' or '%s' step", name, stepTypeSuccess, stepTypeFailure)
+		}
+		step.targets = resolved
+	}
+
+	if start == "" {
+		addProblem("start step is not set")
+		return problems
+	}
+	if _, ok := graph[start]; !ok {
+		addProblem("start step '%s' does not exist", start)
+		return problems
+	}
+
+	// No step is orphaned
+	reachable := map[string]bool{start: true}
+	queue := []string{start}
+	for len(queue) > 0 {
+		current := graph[queue[0]]
+		queue = queue[1:]
+		for _, target := range current.targets {
+			if _, ok := graph[target]; ok && !reachable[target] {
+				reachable[target] = true
+				queue = append(queue, target)
+			}
+		}
+	}
+	for _, name := range names {
+		if _, ok := graph[name]; ok && !reachable[name] {
+			addProblem("step '%s' is not reachable from start step '%s'", name, start)
+		}
+	}
+
+	// Every path ends in an end step
+	endsReachable := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, name := range names {
+			step, ok := graph[name]
+			if !ok || endsReachable[name] {
+				continue
+			}
+			if step.end {
+				endsReachable[name] = true
+				changed = true
+				continue
+			}
+			for _, target := range step.targets {
+				if endsReachable[target] {
+					endsReachable[name] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	for _, name := range names {
+		step, ok := graph[name]
+		if ok && reachable[name] && len(step.targets) > 0 && !endsReachable[name] {
+			addProblem("no path from step '%s' ends in a '%s' or '%s' step", name, stepTypeSuccess, stepTypeFailure)
+		}
+	}
+
+	for _, cycle := range findCycles(start, graph) {
+		addProblem("steps form a cycle without a loop operator: %s", strings.Join(cycle, " -> "))
+	}
+	return problems
+}
+
+func parseWorkflowStep(name string, attributes map[string]interface{}) *workflowStep {
+	stepType, _ := attributes["type"].(string)
+	actionId, _ := attributes["actionId"].(string)
+	step := &workflowStep{
+		name: name,
+		end:  stepType == stepTypeSuccess || stepType == stepTypeFailure,
+		loop: actionId == loopActionId,
+	}
+	if step.end {
+		return step
+	}
+	if stepType == stepTypeChoice {
+		choices, _ := attributes["choices"].([]interface{})
+		for _, choice := range choices {
+			if choiceAttributes, ok := choice.(map[string]interface{}); ok {
+				if nextStep, ok := choiceAttributes["nextStep"].(string); ok {
+					step.targets = append(step.targets, nextStep)
+				}
+			}
+		}
+		if defaultStep, ok := attributes["defaultStep"].(string); ok {
+			step.targets = append(step.targets, defaultStep)
+		}
+		return step
+	}
+	if nextStep, ok := attributes["nextStep"].(string); ok {
+		step.targets = append(step.targets, nextStep)
+	}
+	return step
+}
+
+// validateLoopSteps validates the nested steps of a loop operator, when they are defined.
+func validateLoopSteps(scope string, name string, attributes map[string]interface{}) []string {
+	loopAttributes, _ := attributes["attributes"].(map[string]interface{})
+	loopSteps, ok := loopAttributes["steps"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	loopStart, _ := loopAttributes["start"].(string)
+	return validateStepGraph(scope+"loop '"+name+"': ", loopStart, loopSteps)
+}
+
+// findCycles returns cycles reachable from the start step, which do not go through a loop operator.
+func findCycles(start string, graph map[string]*workflowStep) [][]string {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	var cycles [][]string
+	state := make(map[string]int)
+	var stack []string
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, target := range graph[name].targets {
+			switch state[target] {
+			case visiting:
+				index := len(stack) - 1
+				for stack[index] != target {
+					index--
+				}
+				cycle := append(append([]string{}, stack[index:]...), target)
+				if !containsLoop(cycle, graph) {
+					cycles = append(cycles, cycle)
+				}
+			case 0:
+				visit(target)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = visited
+	}
+	visit(start)
+	return cycles
+}
+
+func containsLoop(cycle []string, graph map[string]*workflowStep) bool {
+	for _, name := range cycle {
+		if graph[name].loop {
+			return true
+		}
+	}
+	return false
+}
+
+// validateWorkflowStepCatalog returns steps which do not match the Workflow Library: unknown or deprecated actions,
+// versions newer than the latest one and missing required attributes. Nested steps of loop operators are checked as well.
+func validateWorkflowStepCatalog(steps map[string]interface{}, catalog map[string]custom.WorkflowLibraryItem) []string {
+	return validateStepCatalog("", steps, catalog)
+}
+
+func validateStepCatalog(scope string, steps map[string]interface{}, catalog map[string]custom.WorkflowLibraryItem) []string {
+	var problems []string
+	addProblem := func(format string, args ...interface{}) {
+		problems = append(problems, scope+fmt.Sprintf(format, args...))
+	}
+
+	names := make([]string, 0, len(steps))
+	for name := range steps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		step, ok := steps[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		actionId, ok := step["actionId"].(string)
+		if !ok {
+			continue
+		}
+		item, ok := catalog[actionId]
+		if !ok {
+			addProblem("step '%s' uses action '%s' which is not in the Workflow Library", name, actionId)
+			continue
+		}
+		if item.Deprecated {
+			addProblem("step '%s' uses deprecated action '%s'", name, actionId)
+		}
+		if version, ok := step["versionNumber"].(float64); ok && item.VersionNumber != nil && int64(version) > *item.VersionNumber {
+			addProblem("step '%s' uses version %d of action '%s', the latest version is %d", name, int64(version), actionId, *item.VersionNumber)
+		}
+		attributes, _ := step["attributes"].(map[string]interface{})
+		for _, formField := range item.FormFields {
+			if !formField.Required || formField.Name == "" {
+				continue
+			}
+			// Attributes with `.$` suffix take the value from a JSONPath expression
+			_, hasValue := attributes[formField.Name]
+			_, hasPath := attributes[formField.Name+".$"]
+			if !hasValue && !hasPath {
+				addProblem("step '%s' is missing attribute '%s' required by action '%s'", name, formField.Name, actionId)
+			}
+		}
+		if actionId == loopActionId {
+			if loopSteps, ok := attributes["steps"].(map[string]interface{}); ok {
+				problems = append(problems, validateStepCatalog(scope+"loop '"+name+"': ", loopSteps, catalog)...)
+			}
+		}
+	}
+	return problems
+}
@@ -0,0 +1,139 @@
+package workflow
+
+import (
+	"encoding/json"
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parseSteps(t *testing.T, steps string) map[string]interface{} {
+	var result map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(steps), &result))
+	return result
+}
+
+func TestValidateWorkflowSteps(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    string
+		steps    string
+		problems []string
+	}{
+		{
+			name:  "valid",
+			start: "Get Identity",
+			steps: `{
+				"Get Identity": {"type": "action", "actionId": "sp:get-identity", "nextStep": "Is Manager"},
+				"Is Manager": {"type": "choice", "choices": [{"comparator": "BooleanEquals", "nextStep": "Send Email"}], "defaultStep": "Failure"},
+				"Send Email": {"type": "action", "actionId": "sp:send-email", "nextStep": "Success"},
+				"Success": {"type": "success"},
+				"Failure": {"type": "failure"}
+			}`,
+		},
+		{
+			name:     "missing start",
+			start:    "Unknown",
+			steps:    `{"Success": {"type": "success"}}`,
+			problems: []string{"start step 'Unknown' does not exist"},
+		},
+		{
+			name:  "unresolved references",
+			start: "Choice",
+			steps: `{
+				"Choice": {"type": "choice", "choices": [{"nextStep": "Typo"}], "defaultStep": "Success"},
+				"Success": {"type": "success"}
+			}`,
+			problems: []string{"step 'Choice' references step 'Typo' which does not exist"},
+		},
+		{
+			name:  "dead end and orphan",
+			start: "Get Identity",
+			steps: `{
+				"Get Identity": {"type": "action", "actionId": "sp:get-identity"},
+				"Success": {"type": "success"}
+			}`,
+			problems: []string{
+				"step 'Get Identity' has no next step, every path must end in a 'success' or 'failure' step",
+				"step 'Success' is not reachable from start step 'Get Identity'",
+			},
+		},
+		{
+			name:  "cycle",
+			start: "A",
+			steps: `{
+				"A": {"type": "action", "nextStep": "B"},
+				"B": {"type": "choice", "choices": [{"nextStep": "A"}], "defaultStep": "Success"},
+				"Success": {"type": "success"}
+			}`,
+			problems: []string{"steps form a cycle without a loop operator: A -> B -> A"},
+		},
+		{
+			name:  "cycle through loop operator",
+			start: "Loop",
+			steps: `{
+				"Loop": {"type": "action", "actionId": "sp:loop:iterator", "nextStep": "Check",
+					"attributes": {"start": "Inner", "steps": {"Inner": {"type": "action", "nextStep": "Inner End"}, "Inner End": {"type": "success"}}}},
+				"Check": {"type": "choice", "choices": [{"nextStep": "Loop"}], "defaultStep": "Success"},
+				"Success": {"type": "success"}
+			}`,
+		},
+		{
+			name:  "cycle without exit",
+			start: "A",
+			steps: `{
+				"A": {"type": "action", "actionId": "sp:loop:iterator", "nextStep": "B"},
+				"B": {"type": "action", "nextStep": "A"},
+				"Success": {"type": "success"}
+			}`,
+			problems: []string{
+				"step 'Success' is not reachable from start step 'A'",
+				"no path from step 'A' ends in a 'success' or 'failure' step",
+				"no path from step 'B' ends in a 'success' or 'failure' step",
+			},
+		},
+		{
+			name:  "invalid nested loop steps",
+			start: "Loop",
+			steps: `{
+				"Loop": {"type": "action", "actionId": "sp:loop:iterator", "nextStep": "Success",
+					"attributes": {"start": "Missing", "steps": {"Inner End": {"type": "success"}}}},
+				"Success": {"type": "success"}
+			}`,
+			problems: []string{"loop 'Loop': start step 'Missing' does not exist"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.problems, validateWorkflowSteps(test.start, parseSteps(t, test.steps)))
+		})
+	}
+}
+
+func TestValidateWorkflowStepCatalog(t *testing.T) {
+	latest := int64(2)
+	catalog := map[string]custom.WorkflowLibraryItem{
+		"sp:send-email": {
+			Id:            "sp:send-email",
+			VersionNumber: &latest,
+			FormFields:    []custom.WorkflowLibraryFormField{{Name: "recipientEmailList", Required: true}, {Name: "body"}},
+		},
+		"sp:http":          {Id: "sp:http", Deprecated: true},
+		"sp:loop:iterator": {Id: "sp:loop:iterator"},
+	}
+	steps := parseSteps(t, `{
+		"Email": {"type": "action", "actionId": "sp:send-email", "versionNumber": 2, "attributes": {"recipientEmailList.$": "$.trigger.email"}},
+		"Future Email": {"type": "action", "actionId": "sp:send-email", "versionNumber": 3, "attributes": {"recipientEmailList": ["a@b.c"]}},
+		"Incomplete Email": {"type": "action", "actionId": "sp:send-email", "attributes": {"body": "Hello"}},
+		"HTTP": {"type": "action", "actionId": "sp:http"},
+		"Loop": {"type": "action", "actionId": "sp:loop:iterator", "attributes": {"steps": {"Inner": {"type": "action", "actionId": "sp:unknown"}}}},
+		"Success": {"type": "success"}
+	}`)
+	assert.Equal(t, []string{
+		"step 'Future Email' uses version 3 of action 'sp:send-email', the latest version is 2",
+		"step 'HTTP' uses deprecated action 'sp:http'",
+		"step 'Incomplete Email' is missing attribute 'recipientEmailList' required by action 'sp:send-email'",
+		"loop 'Loop': step 'Inner' uses action 'sp:unknown' which is not in the Workflow Library",
+	}, validateWorkflowStepCatalog(steps, catalog))
+}
@@ -0,0 +1,12 @@
+package workflow
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type workflowExternalTriggerModel struct {
+	Id               types.String `tfsdk:"id"`
+	WorkflowId       types.String `tfsdk:"workflow_id"`
+	RotationTriggers types.Map    `tfsdk:"rotation_triggers"`
+	ClientId         types.String `tfsdk:"client_id"`
+	ClientSecret     types.String `tfsdk:"client_secret"`
+	Url              types.String `tfsdk:"url"`
+}
@@ -0,0 +1,243 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"terraform-provider-identitynow/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sailpoint "github.com/sailpoint-oss/golang-sdk/v2"
+	sailpointBeta "github.com/sailpoint-oss/golang-sdk/v2/api_beta"
+	sailpointV3 "github.com/sailpoint-oss/golang-sdk/v2/api_v3"
+)
+
+// Implementation of OAuth client of EXTERNAL workflow triggers - https://developer.sailpoint.com/docs/api/beta/post-workflow-external-trigger
+var (
+	_ resource.Resource              = &workflowExternalTriggerResource{}
+	_ resource.ResourceWithConfigure = &workflowExternalTriggerResource{}
+)
+
+const externalTriggerType = "EXTERNAL"
+
+func NewWorkflowExternalTriggerResource() resource.Resource {
+	return &workflowExternalTriggerResource{}
+}
+
+type workflowExternalTriggerResource struct {
+	apiClient *sailpoint.APIClient
+}
+
+func (r *workflowExternalTriggerResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*custom.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *sailpoint.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.apiClient = client.ApiClient
+}
+
+func (r *workflowExternalTriggerResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_external_trigger"
+}
+
+func (r *workflowExternalTriggerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "OAuth client used by external systems to invoke a workflow with `EXTERNAL` trigger. " +
+			"The client secret is only returned when the client is generated, so the resource cannot be imported. " +
+			"A new client is generated when the client is deleted or the workflow no longer has an `EXTERNAL` trigger.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the OAuth client",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workflow_id": schema.StringAttribute{
+				Description: "The ID of the workflow with `EXTERNAL` trigger",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotation_triggers": schema.MapAttribute{
+				Description: "Arbitrary values which generate a new client id and secret when changed, e.g. a rotation date",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"client_id": schema.StringAttribute{
+				Description: "The client id used to request an access token",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_secret": schema.StringAttribute{
+				Description: "The client secret used to request an access token",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				Description: "The URL receiving the trigger payload which starts the workflow",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *workflowExternalTriggerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan workflowExternalTriggerModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workflowId := plan.WorkflowId.ValueString()
+	workflow, spResp, err := r.apiClient.Beta.WorkflowsAPI.GetWorkflow(ctx, workflowId).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Workflow External Trigger",
+			"Could not read Workflow '"+workflowId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+	if triggerType := workflowTriggerType(workflow); triggerType != externalTriggerType {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("workflow_id"),
+			"Invalid Workflow Trigger Type",
+			"Workflow '"+workflowId+"' must have a trigger of type '"+externalTriggerType+"' to get an external trigger, got: "+triggerType,
+		)
+		return
+	}
+
+	client, spResp, err := r.apiClient.Beta.WorkflowsAPI.PostWorkflowExternalTrigger(ctx, workflowId).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Workflow External Trigger",
+			"Could not create external trigger of Workflow '"+workflowId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+
+	r.mapToTerraformModel(&plan, client)
+
+	resp.Diagnostics.Append(util.MarkCreated(ctx, resp.Private)...)
+	// Set state to fully populated data
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read checks the workflow still has an `EXTERNAL` trigger and the OAuth client still exists, otherwise the resource is
+// removed from state so a new client is generated. The client secret cannot be read back.
+func (r *workflowExternalTriggerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state workflowExternalTriggerModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	workflowId := state.WorkflowId.ValueString()
+
+	workflow, spResp, err := util.ReadAfterWrite(ctx, req.Private, func() (*sailpointBeta.Workflow, *http.Response, error) {
+		return r.apiClient.Beta.WorkflowsAPI.GetWorkflow(ctx, workflowId).Execute()
+	})
+	if util.IsNotFound(spResp) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Workflow",
+			"Could not read Workflow '"+workflowId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+	if triggerType := workflowTriggerType(workflow); triggerType != externalTriggerType {
+		tflog.Info(ctx, "Workflow '"+workflowId+"' has no "+externalTriggerType+" trigger anymore, got: "+triggerType)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	clientId := state.ClientId.ValueString()
+	_, spResp, err = util.ReadAfterWrite(ctx, req.Private, func() (*sailpointV3.GetOAuthClientResponse, *http.Response, error) {
+		return r.apiClient.V3.OAuthClientsAPI.GetOauthClient(ctx, clientId).Execute()
+	})
+	if util.IsNotFound(spResp) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Workflow External Trigger",
+			"Could not read OAuth client '"+clientId+"' of Workflow '"+workflowId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+}
+
+// Update only stores the plan, every configurable attribute requires replacement.
+func (r *workflowExternalTriggerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan workflowExternalTriggerModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete revokes the OAuth client. A client already replaced by a newer one is not found, which is not an error.
+func (r *workflowExternalTriggerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state workflowExternalTriggerModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spResp, err := r.apiClient.V3.OAuthClientsAPI.DeleteOauthClient(ctx, state.ClientId.ValueString()).Execute()
+	if err != nil && !util.IsNotFound(spResp) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Workflow External Trigger",
+			"Could not delete OAuth client '"+state.ClientId.ValueString()+"' of Workflow '"+state.WorkflowId.ValueString()+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+}
+
+// workflowTriggerType returns the type of the trigger of the workflow, or "none".
+func workflowTriggerType(workflow *sailpointBeta.Workflow) string {
+	if workflow.Trigger == nil || workflow.Trigger.Type == "" {
+		return "none"
+	}
+	return workflow.Trigger.Type
+}
+
+func (r *workflowExternalTriggerResource) mapToTerraformModel(model *workflowExternalTriggerModel, client *sailpointBeta.WorkflowOAuthClient) {
+	model.Id = types.StringPointerValue(client.Id)
+	model.ClientId = types.StringPointerValue(client.Id)
+	model.ClientSecret = types.StringPointerValue(client.Secret)
+	model.Url = types.StringPointerValue(client.Url)
+}
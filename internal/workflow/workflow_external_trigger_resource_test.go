@@ -0,0 +1,15 @@
+package workflow
+
+import (
+	"testing"
+
+	sailpointBeta "github.com/sailpoint-oss/golang-sdk/v2/api_beta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowTriggerType(t *testing.T) {
+	assert.Equal(t, "EXTERNAL", workflowTriggerType(&sailpointBeta.Workflow{Trigger: &sailpointBeta.WorkflowTrigger{Type: "EXTERNAL"}}))
+	assert.Equal(t, "EVENT", workflowTriggerType(&sailpointBeta.Workflow{Trigger: &sailpointBeta.WorkflowTrigger{Type: "EVENT"}}))
+	assert.Equal(t, "none", workflowTriggerType(&sailpointBeta.Workflow{Trigger: &sailpointBeta.WorkflowTrigger{}}))
+	assert.Equal(t, "none", workflowTriggerType(&sailpointBeta.Workflow{}))
+}
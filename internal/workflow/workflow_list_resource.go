@@ -0,0 +1,60 @@
+package workflow
+
+import (
+	"context"
+	"terraform-provider-identitynow/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sailpointBeta "github.com/sailpoint-oss/golang-sdk/v2/api_beta"
+)
+
+// Implementation of IdentityNow Workflow listing for `terraform query` - https://developer.sailpoint.com/idn/api/beta/list-workflows
+var (
+	_ list.ListResource              = &workflowListResource{}
+	_ list.ListResourceWithConfigure = &workflowListResource{}
+)
+
+func NewWorkflowListResource() list.ListResource {
+	return &workflowListResource{}
+}
+
+// workflowListResource shares Configure, Metadata and the state mapping with the managed resource.
+type workflowListResource struct {
+	workflowResource
+}
+
+func (r *workflowListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{}
+}
+
+func (r *workflowListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	workflows, spResp, err := r.apiClient.Beta.WorkflowsAPI.ListWorkflows(ctx).Execute()
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Error Listing Workflows",
+			"Could not list Workflows: "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = util.StreamListResults(ctx, req, workflows, func(workflow *sailpointBeta.Workflow, result *list.ListResult) {
+		if workflow.Name != nil {
+			result.DisplayName = *workflow.Name
+		}
+		util.SetIdResourceIdentity(ctx, result.Identity, types.StringPointerValue(workflow.Id), &result.Diagnostics)
+		if !req.IncludeResource {
+			return
+		}
+		var model workflowModel
+		r.mapToTerraformModel(&model, workflow, &result.Diagnostics)
+		if result.Diagnostics.HasError() {
+			return
+		}
+		result.Diagnostics.Append(result.Resource.Set(ctx, &model)...)
+	})
+}
@@ -15,6 +15,10 @@ type workflowModel struct {
 	Enabled     types.Bool          `tfsdk:"enabled"`
 	Definition  *definition         `tfsdk:"definition"`
 	Trigger     *trigger            `tfsdk:"trigger"`
+	// UI export used instead of Definition and Trigger
+	DefinitionJson jsontypes.Normalized `tfsdk:"definition_json"`
+	NextFireTimes  types.List           `tfsdk:"next_fire_times"`
+	TestInput      jsontypes.Normalized `tfsdk:"test_input"`
 }
 
 type definition struct {
@@ -39,5 +43,5 @@ type triggerAttributes struct {
 	Description types.String `tfsdk:"description" json:"description"`
 
 	// Fields for SCHEDULED type of trigger
-	CronString types.String `tfsdk:"cron_string" json:"cronString"`
+	CronString util.CronExpressionValue `tfsdk:"cron_string" json:"cronString"`
 }
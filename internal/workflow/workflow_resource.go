@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"reflect"
 	"strconv"
 	"terraform-provider-identitynow/internal/patch"
 	"terraform-provider-identitynow/internal/sailpoint/custom"
@@ -12,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -27,8 +29,11 @@ import (
 )
 
 var (
-	_ resource.Resource              = &workflowResource{}
-	_ resource.ResourceWithConfigure = &workflowResource{}
+	_ resource.Resource                = &workflowResource{}
+	_ resource.ResourceWithConfigure   = &workflowResource{}
+	_ resource.ResourceWithImportState = &workflowResource{}
+	_ resource.ResourceWithIdentity    = &workflowResource{}
+	_ resource.ResourceWithModifyPlan  = &workflowResource{}
 )
 
 func NewWorkflowResource() resource.Resource {
@@ -36,7 +41,8 @@ func NewWorkflowResource() resource.Resource {
 }
 
 type workflowResource struct {
-	apiClient *sailpoint.APIClient
+	apiClient    *sailpoint.APIClient
+	customClient *custom.APIClient
 }
 
 func (r *workflowResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -53,12 +59,17 @@ func (r *workflowResource) Configure(ctx context.Context, req resource.Configure
 	}
 
 	r.apiClient = client.ApiClient
+	r.customClient = client
 }
 
 func (r *workflowResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_workflow"
 }
 
+func (r *workflowResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = util.IdResourceIdentitySchema()
+}
+
 func (r *workflowResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
@@ -108,8 +119,11 @@ func (r *workflowResource) Schema(ctx context.Context, req resource.SchemaReques
 				Optional:    true,
 			},
 			"definition": schema.SingleNestedAttribute{
-				Description: "The map of steps that the workflow will execute",
+				Description: "The map of steps that the workflow will execute. The graph of steps is validated during plan: the start step and all `nextStep`, `choices` and `defaultStep` references must exist, every path must end in a `success` or `failure` step, all steps must be reachable and cycles are only allowed through loop operators",
 				Optional:    true,
+				Validators: []validator.Object{
+					definitionValidator{},
+				},
 				Attributes: map[string]schema.Attribute{
 					"start": schema.StringAttribute{
 						Description: "The name of the starting step",
@@ -122,12 +136,27 @@ func (r *workflowResource) Schema(ctx context.Context, req resource.SchemaReques
 					},
 				},
 			},
+			"definition_json": schema.StringAttribute{
+				Description: "Workflow exported from the visual builder of the UI, used instead of `definition` and `trigger`. Only `definition` and `trigger` of the export are used, other keys (e.g. `id`, `creator`, `created`, `modified`) and step positions are ignored. Trigger attributes other than `id`, `filter.$`, `attributeToFilter`, `name`, `description` and `cronString` are rejected",
+				Optional:    true,
+				CustomType:  jsontypes.NormalizedType{},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("definition"), path.MatchRoot("trigger")),
+					definitionJsonValidator{},
+				},
+			},
 			"enabled": schema.BoolAttribute{
 				Description: "Enable or disable the workflow. Workflows cannot be created in an enabled state",
 				Optional:    true,
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"next_fire_times": util.CronNextFireTimesAttribute(path.Root("trigger").AtName("attributes").AtName("cron_string")),
+			"test_input": schema.StringAttribute{
+				Description: "JSON input of a test execution run after create and after every update changing `definition`, `trigger` or `definition_json`. The workflow is enabled only when the test execution completes, otherwise the apply fails with the history of the executed steps",
+				Optional:    true,
+				CustomType:  jsontypes.NormalizedType{},
+			},
 			"trigger": schema.SingleNestedAttribute{
 				Description: "The trigger that starts the workflow",
 				Optional:    true,
@@ -168,8 +197,9 @@ func (r *workflowResource) Schema(ctx context.Context, req resource.SchemaReques
 							},
 
 							"cron_string": schema.StringAttribute{
-								Description: "A valid CRON expression. SCHEDULED trigger type",
+								Description: "A valid CRON expression, in Quartz (`0 0 12 * * ?`) or standard (`0 12 * * *`) format. The expression is validated during plan and equivalent expressions do not cause a diff. SCHEDULED trigger type",
 								Optional:    true,
+								CustomType:  util.CronExpressionType{},
 							},
 						},
 					},
@@ -179,6 +209,36 @@ func (r *workflowResource) Schema(ctx context.Context, req resource.SchemaReques
 	}
 }
 
+// ModifyPlan compares changed steps with the Workflow Library, so unknown actions or missing attributes are reported during plan.
+func (r *workflowResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.customClient == nil {
+		return
+	}
+	stepsPath := path.Root("definition").AtName("steps")
+	var planSteps, stateSteps jsontypes.Exact
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, stepsPath, &planSteps)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, stepsPath, &stateSteps)...)
+	}
+	if resp.Diagnostics.HasError() || planSteps.IsNull() || planSteps.IsUnknown() || planSteps.Equal(stateSteps) {
+		return
+	}
+	var steps map[string]interface{}
+	if planSteps.Unmarshal(&steps).HasError() {
+		// Reported by definitionValidator
+		return
+	}
+
+	catalog, err := r.customClient.WorkflowStepCatalog(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Workflow steps are not checked against the Workflow Library: "+err.Error())
+		return
+	}
+	for _, problem := range validateWorkflowStepCatalog(steps, catalog) {
+		resp.Diagnostics.AddAttributeWarning(stepsPath, "Workflow Step Does Not Match Workflow Library", problem)
+	}
+}
+
 func (r *workflowResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan workflowModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -197,7 +257,7 @@ func (r *workflowResource) Create(ctx context.Context, req resource.CreateReques
 
 	tflog.Info(ctx, "Creating Workflow "+util.PrettyPrint(workflow))
 	workflowResp, spResp, err := r.apiClient.Beta.WorkflowsAPI.CreateWorkflow(ctx).CreateWorkflowRequest(workflow).Execute()
-	if err != nil && spResp.StatusCode != http.StatusCreated {
+	if err != nil && (spResp == nil || spResp.StatusCode != http.StatusCreated) {
 		resp.Diagnostics.AddError(
 			"Error Creating Workflow",
 			"Could not create Workflow '"+plan.Name.ValueString()+"': "+err.Error()+"\n"+util.GetBody(spResp),
@@ -205,6 +265,19 @@ func (r *workflowResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	if !plan.TestInput.IsNull() {
+		plan.Id = types.StringPointerValue(workflowResp.Id)
+		errorMsg, err := r.runWorkflowTest(ctx, &plan)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Testing Workflow",
+				errorMsg,
+			)
+			r.savePartiallyCreated(ctx, &plan, workflowResp, resp)
+			return
+		}
+	}
+
 	if enabledAfterCreation == true {
 		tflog.Info(ctx, "Enabling Workflow '"+*workflowResp.Name+"' after creation")
 		errorMsg, err := r.enableDisableWorkflowById(*workflowResp.Id, true)
@@ -213,29 +286,55 @@ func (r *workflowResource) Create(ctx context.Context, req resource.CreateReques
 				"Error enabling Workflow after creation",
 				errorMsg,
 			)
+			r.savePartiallyCreated(ctx, &plan, workflowResp, resp)
 			return
 		}
 		workflowResp.Enabled = sailpointBeta.PtrBool(true)
 	}
 
+	// Fire times planned from state are kept, so the result matches the plan
+	plannedFireTimes := plan.NextFireTimes
 	r.mapToTerraformModel(&plan, workflowResp, &resp.Diagnostics)
+	if !plannedFireTimes.IsUnknown() {
+		plan.NextFireTimes = plannedFireTimes
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	util.SetIdResourceIdentity(ctx, resp.Identity, plan.Id, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(util.MarkCreated(ctx, resp.Private)...)
+
 	// Set state to fully populated data
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
+// savePartiallyCreated keeps the created Workflow in state after a failed Create, so Terraform taints it instead of losing track of it.
+func (r *workflowResource) savePartiallyCreated(ctx context.Context, plan *workflowModel, workflow *sailpointBeta.Workflow, resp *resource.CreateResponse) {
+	var diagnostics diag.Diagnostics
+	r.mapToTerraformModel(plan, workflow, &diagnostics)
+	util.SetIdResourceIdentity(ctx, resp.Identity, plan.Id, &diagnostics)
+	diagnostics.Append(util.MarkCreated(ctx, resp.Private)...)
+	diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(diagnostics...)
+	if !diagnostics.HasError() {
+		util.AddPartiallyCreatedWarning(&resp.Diagnostics, "Workflow", plan.Id.ValueString())
+	}
+}
+
 func (r *workflowResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state workflowModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	util.SetIdResourceIdentity(ctx, resp.Identity, state.Id, &resp.Diagnostics)
 
-	workflowResp, spResp, err := r.apiClient.Beta.WorkflowsAPI.GetWorkflow(ctx, state.Id.ValueString()).Execute()
-	if spResp.StatusCode == 404 {
+	workflowResp, spResp, err := util.ReadAfterWrite(ctx, req.Private, func() (*sailpointBeta.Workflow, *http.Response, error) {
+		return r.apiClient.Beta.WorkflowsAPI.GetWorkflow(ctx, state.Id.ValueString()).Execute()
+	})
+	if util.IsNotFound(spResp) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -264,10 +363,21 @@ func (r *workflowResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	// If we are patching and the workflow is enabled, but we need to disable it first.
+	newModel := r.convertToAPIModel(&plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	oldModel := r.convertToAPIModel(&state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Other changes, e.g. of the name or description, are patched without touching `enabled`
+	definitionChanged := workflowDefinitionChanged(&newModel, &oldModel)
+
+	// If the definition changes and the workflow is enabled, we need to disable it first.
 	// In case we need to re-enable it after the PATCH, that will be handled automatically by the PATCH,
 	// as it always adds the enabled operations in the last place.
-	if state.Enabled.ValueBool() {
+	if definitionChanged && state.Enabled.ValueBool() {
 		tflog.Info(ctx, "Disabling Workflow '"+state.Name.ValueString()+"' before PATCH.")
 		errorMsg, err := r.enableDisableWorkflow(state, false)
 		if err != nil {
@@ -278,16 +388,13 @@ func (r *workflowResource) Update(ctx context.Context, req resource.UpdateReques
 			)
 			return
 		}
-		state.Enabled = types.BoolValue(false)
+		oldModel.Enabled = sailpointBeta.PtrBool(false)
 	}
-
-	newModel := r.convertToAPIModel(&plan, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	oldModel := r.convertToAPIModel(&state, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
-		return
+	// A changed workflow with `test_input` is tested and enabled only after the test execution completes
+	testAfterPatch := definitionChanged && !plan.TestInput.IsNull()
+	enabledAfterTest := testAfterPatch && plan.Enabled.ValueBool()
+	if enabledAfterTest {
+		newModel.Enabled = sailpointBeta.PtrBool(false)
 	}
 
 	jsonPatch, err := patch.NewWorkflowPatchBuilder(&newModel, &oldModel).GenerateJsonPatch()
@@ -301,7 +408,7 @@ func (r *workflowResource) Update(ctx context.Context, req resource.UpdateReques
 	tflog.Info(ctx, "Patches to apply: "+util.PrettyPrint(jsonPatch))
 
 	workflowResp, spResp, err := r.apiClient.Beta.WorkflowsAPI.PatchWorkflow(ctx, state.Id.ValueString()).JsonPatchOperation(jsonPatch).Execute()
-	if err != nil || (spResp != nil && spResp.StatusCode != http.StatusOK) {
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Workflow",
 			"Could not update Workflow '"+plan.Name.ValueString()+"': "+err.Error()+"\n"+util.GetBody(spResp),
@@ -309,11 +416,46 @@ func (r *workflowResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	if testAfterPatch {
+		errorMsg, err := r.runWorkflowTest(ctx, &plan)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Testing Workflow",
+				errorMsg,
+			)
+			// Keep the updated, but disabled, workflow in state
+			r.mapToTerraformModel(&plan, workflowResp, &resp.Diagnostics)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			return
+		}
+	}
+	if enabledAfterTest {
+		tflog.Info(ctx, "Enabling Workflow '"+plan.Name.ValueString()+"' after test")
+		errorMsg, err := r.enableDisableWorkflow(plan, true)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Enabling Workflow",
+				errorMsg,
+			)
+			r.mapToTerraformModel(&plan, workflowResp, &resp.Diagnostics)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			return
+		}
+		workflowResp.Enabled = sailpointBeta.PtrBool(true)
+	}
+
+	// Fire times planned from state are kept, so the result matches the plan
+	plannedFireTimes := plan.NextFireTimes
 	r.mapToTerraformModel(&plan, workflowResp, &resp.Diagnostics)
+	if !plannedFireTimes.IsUnknown() {
+		plan.NextFireTimes = plannedFireTimes
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	util.SetIdResourceIdentity(ctx, resp.Identity, plan.Id, &resp.Diagnostics)
+
 	// Set refreshed state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -372,6 +514,12 @@ func (r *workflowResource) enableDisableWorkflowById(id string, status bool) (st
 	return "", nil
 }
 
+// workflowDefinitionChanged reports whether the steps or the trigger of a workflow change, configured either by
+// `definition` and `trigger` or by `definition_json`.
+func workflowDefinitionChanged(newModel, oldModel *sailpointBeta.CreateWorkflowRequest) bool {
+	return !reflect.DeepEqual(newModel.Definition, oldModel.Definition) || !reflect.DeepEqual(newModel.Trigger, oldModel.Trigger)
+}
+
 func (r *workflowResource) convertToAPIModel(model *workflowModel, diagnostics *diag.Diagnostics) sailpointBeta.CreateWorkflowRequest {
 	var modelDef *sailpointBeta.WorkflowDefinition
 	if model.Definition != nil {
@@ -392,6 +540,9 @@ func (r *workflowResource) convertToAPIModel(model *workflowModel, diagnostics *
 			Attributes: attributes,
 		}
 	}
+	if export := definitionJsonOrNull(model, diagnostics); export != nil {
+		modelDef, wfTrigger = r.convertFromWorkflowExport(export)
+	}
 	return sailpointBeta.CreateWorkflowRequest{
 		Name: model.Name.ValueString(),
 		Owner: sailpointBeta.WorkflowBodyOwner{
@@ -443,6 +594,16 @@ func (r *workflowResource) mapToTerraformModel(model *workflowModel, workflow *s
 	model.Owner = *util.NewPointerReferenceModel(workflow.Owner.Type, workflow.Owner.Id, workflow.Owner.Name)
 	model.Description = types.StringPointerValue(workflow.Description)
 	model.Enabled = types.BoolPointerValue(workflow.Enabled)
+	model.NextFireTimes = types.ListNull(types.StringType)
+	if workflow.Trigger != nil && workflow.Trigger.Type == "SCHEDULED" {
+		if attributes := workflow.Trigger.Attributes.Get(); attributes != nil && attributes.ScheduledAttributes != nil {
+			model.NextFireTimes = util.NewCronExpressionPointerValue(attributes.ScheduledAttributes.CronString).NextFireTimes()
+		}
+	}
+	if !model.DefinitionJson.IsNull() {
+		model.DefinitionJson = r.mapToDefinitionJson(workflow, model.DefinitionJson, diagnostic)
+		return
+	}
 	if workflow.Definition != nil {
 		model.Definition = &definition{
 			Start: types.StringPointerValue(workflow.Definition.Start),
@@ -493,7 +654,7 @@ func (r *workflowResource) convertToTriggerAttributes(triggerType string, attrib
 		if scheduledAttrs != nil && scheduledAttrs.CronString != nil {
 			cron = *scheduledAttrs.CronString
 		}
-		returnObject.CronString = types.StringValue(cron)
+		returnObject.CronString = util.NewCronExpressionValue(cron)
 	}
 
 	return returnObject
@@ -505,3 +666,49 @@ func (r *workflowResource) getTFString(attributes map[string]interface{}, key st
 	}
 	return types.StringNull()
 }
+
+func (r *workflowResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	key := util.ParseImportKey(util.GetImportId(ctx, req, &resp.Diagnostics), "workflow")
+	var workflow *sailpointBeta.Workflow
+	if key.IsName() {
+		workflows, spResp, err := r.apiClient.Beta.WorkflowsAPI.ListWorkflows(ctx).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Workflow",
+				"Could not list Workflows: "+err.Error()+"\n"+util.GetBody(spResp),
+			)
+			return
+		}
+		for i := range workflows {
+			if workflows[i].Name != nil && *workflows[i].Name == key.Name {
+				workflow = &workflows[i]
+				break
+			}
+		}
+		if workflow == nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Workflow",
+				"Could not find Workflow '"+key.Name+"'",
+			)
+			return
+		}
+	} else {
+		workflowResp, spResp, err := r.apiClient.Beta.WorkflowsAPI.GetWorkflow(ctx, key.Id).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Workflow",
+				"Could not find Workflow '"+key.Id+"': "+err.Error()+"\n"+util.GetBody(spResp),
+			)
+			return
+		}
+		workflow = workflowResp
+	}
+
+	var state workflowModel
+	r.mapToTerraformModel(&state, workflow, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	util.SetIdResourceIdentity(ctx, resp.Identity, state.Id, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,29 @@
+package workflow
+
+import (
+	"testing"
+
+	sailpointBeta "github.com/sailpoint-oss/golang-sdk/v2/api_beta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowDefinitionChanged(t *testing.T) {
+	start := "get-identity"
+	definition := func(steps map[string]interface{}) *sailpointBeta.WorkflowDefinition {
+		return &sailpointBeta.WorkflowDefinition{Start: &start, Steps: steps}
+	}
+	trigger := func(triggerType string) *sailpointBeta.WorkflowTrigger {
+		return &sailpointBeta.WorkflowTrigger{Type: triggerType}
+	}
+	steps := map[string]interface{}{"get-identity": map[string]interface{}{"type": "action"}}
+	oldModel := sailpointBeta.CreateWorkflowRequest{Name: "Old", Enabled: sailpointBeta.PtrBool(true), Definition: definition(steps), Trigger: trigger("EVENT")}
+
+	renamed := sailpointBeta.CreateWorkflowRequest{Name: "New", Enabled: sailpointBeta.PtrBool(false), Definition: definition(map[string]interface{}{"get-identity": map[string]interface{}{"type": "action"}}), Trigger: trigger("EVENT")}
+	assert.False(t, workflowDefinitionChanged(&renamed, &oldModel))
+
+	changedSteps := sailpointBeta.CreateWorkflowRequest{Name: "Old", Definition: definition(map[string]interface{}{"get-identity": map[string]interface{}{"type": "success"}}), Trigger: trigger("EVENT")}
+	assert.True(t, workflowDefinitionChanged(&changedSteps, &oldModel))
+
+	changedTrigger := sailpointBeta.CreateWorkflowRequest{Name: "Old", Definition: definition(steps), Trigger: trigger("SCHEDULED")}
+	assert.True(t, workflowDefinitionChanged(&changedTrigger, &oldModel))
+}
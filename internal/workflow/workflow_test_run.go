@@ -0,0 +1,104 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"terraform-provider-identitynow/internal/util"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sailpointBeta "github.com/sailpoint-oss/golang-sdk/v2/api_beta"
+)
+
+// Interval between reads of a test execution and the longest time to wait for it to finish.
+var (
+	testExecutionPollInterval = 2 * time.Second
+	testExecutionTimeout      = 10 * time.Minute
+)
+
+// runWorkflowTest invokes the workflow with `test_input` and waits for the execution to finish. When the execution does
+// not complete, the error message contains the history of the executed steps.
+func (r *workflowResource) runWorkflowTest(ctx context.Context, model *workflowModel) (string, error) {
+	var diagnostics diag.Diagnostics
+	input := util.UnmarshalJsonTypeNormalized(model.TestInput, &diagnostics)
+	if diagnostics.HasError() {
+		return "Could not parse test_input of Workflow '" + model.Name.ValueString() + "'", errors.New("invalid test_input")
+	}
+
+	tflog.Info(ctx, "Testing Workflow '"+model.Name.ValueString()+"'")
+	testResp, spResp, err := r.apiClient.Beta.WorkflowsAPI.TestWorkflow(ctx, model.Id.ValueString()).
+		TestWorkflowRequest(sailpointBeta.TestWorkflowRequest{Input: input}).Execute()
+	if err != nil {
+		return "Could not test Workflow '" + model.Name.ValueString() + "': " + err.Error() + "\n" + util.GetBody(spResp), err
+	}
+	executionId := testResp.GetWorkflowExecutionId()
+
+	execution, err := util.PollUntil(ctx, testExecutionPollInterval, testExecutionTimeout, func(ctx context.Context) (*custom.WorkflowExecution, bool, error) {
+		execution, response, err := r.customClient.GetWorkflowExecution(ctx, executionId)
+		spResp = response
+		// The execution may not be readable right after the test started
+		if err != nil && !util.IsNotFound(response) {
+			return nil, false, err
+		}
+		return execution, execution != nil && execution.Finished(), nil
+	})
+	if errors.Is(err, util.ErrNotCompleted) {
+		return "Test execution '" + executionId + "' of Workflow '" + model.Name.ValueString() + "' did not finish in " + testExecutionTimeout.String(), err
+	}
+	if err != nil {
+		return "Could not read test execution '" + executionId + "' of Workflow '" + model.Name.ValueString() + "': " + err.Error() + "\n" + util.GetBody(spResp), err
+	}
+	if execution.Status == custom.WorkflowExecutionStatusCompleted {
+		return "", nil
+	}
+
+	message := "Test execution '" + executionId + "' of Workflow '" + model.Name.ValueString() + "' ended with status '" + execution.Status + "'"
+	history, spResp, err := r.customClient.GetWorkflowExecutionHistory(ctx, executionId)
+	if err != nil {
+		message += ", its history could not be read: " + err.Error() + "\n" + util.GetBody(spResp)
+	} else {
+		message += ":\n" + formatExecutionHistory(history)
+	}
+	return message, errors.New("workflow test execution " + strings.ToLower(execution.Status))
+}
+
+// formatExecutionHistory lists the events of an execution, one per line. Attributes are included for failed events only,
+// as other events may carry large step outputs.
+func formatExecutionHistory(events []custom.WorkflowExecutionEvent) string {
+	var lines []string
+	for _, event := range events {
+		line := "- "
+		if event.Timestamp != nil {
+			line += event.Timestamp.UTC().Format(time.RFC3339) + " "
+		}
+		line += event.Type
+		if step := event.StepName(); step != "" {
+			line += fmt.Sprintf(" (step '%s')", step)
+		}
+		if strings.HasSuffix(event.Type, "Failed") || strings.HasSuffix(event.Type, "TimedOut") {
+			line += ": " + formatEventAttributes(event.Attributes)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatEventAttributes(attributes map[string]interface{}) string {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, _ := json.Marshal(attributes[key])
+		parts = append(parts, key+"="+string(value))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,22 @@
+package workflow
+
+import (
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatExecutionHistory(t *testing.T) {
+	timestamp := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	history := []custom.WorkflowExecutionEvent{
+		{Type: "WorkflowExecutionStarted", Timestamp: &timestamp},
+		{Type: "ActivityTaskScheduled", Attributes: map[string]interface{}{"displayName": "Get Identity", "input": map[string]interface{}{"id": "1"}}},
+		{Type: "ActivityTaskFailed", Attributes: map[string]interface{}{"stepName": "get-identity-1", "error": "identity not found"}},
+	}
+	assert.Equal(t, "- 2024-10-01T12:00:00Z WorkflowExecutionStarted\n"+
+		"- ActivityTaskScheduled (step 'Get Identity')\n"+
+		"- ActivityTaskFailed (step 'get-identity-1'): error=\"identity not found\", stepName=\"get-identity-1\"",
+		formatExecutionHistory(history))
+}
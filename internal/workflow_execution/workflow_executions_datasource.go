@@ -0,0 +1,251 @@
+package workflow_execution
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"terraform-provider-identitynow/internal/util"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Implementation of IdentityNow Workflow executions - https://developer.sailpoint.com/docs/api/beta/get-workflow-executions
+var (
+	_ datasource.DataSource              = &workflowExecutionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &workflowExecutionsDataSource{}
+)
+
+const defaultExecutionsLimit = 25
+
+func NewWorkflowExecutionsDataSource() datasource.DataSource {
+	return &workflowExecutionsDataSource{}
+}
+
+type workflowExecutionsDataSource struct {
+	apiClient *custom.APIClient
+}
+
+func (d *workflowExecutionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*custom.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *sailpoint.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.apiClient = client
+}
+
+func (d *workflowExecutionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_executions"
+}
+
+func (d *workflowExecutionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	executionAttributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "The ID of the execution",
+			Computed:    true,
+		},
+		"request_id": schema.StringAttribute{
+			Description: "The ID of the request which started the execution",
+			Computed:    true,
+		},
+		"trigger_type": schema.StringAttribute{
+			Description: "The type of trigger which started the execution: `EVENT`, `EXTERNAL` or `SCHEDULED`, null when it is not reported",
+			Computed:    true,
+		},
+		"status": schema.StringAttribute{
+			Description: "The status of the execution: `Queued`, `Running`, `Completed`, `Failed` or `Canceled`",
+			Computed:    true,
+		},
+		"start_time": schema.StringAttribute{
+			Description: "The time the execution started (RFC 3339)",
+			Computed:    true,
+		},
+		"close_time": schema.StringAttribute{
+			Description: "The time the execution ended (RFC 3339), null while it is running",
+			Computed:    true,
+		},
+	}
+	resp.Schema = schema.Schema{
+		Description: "Latest executions of a workflow, newest first",
+		Attributes: map[string]schema.Attribute{
+			"workflow_id": schema.StringAttribute{
+				Description: "The ID of the workflow",
+				Required:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Only return executions with the status",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Queued", "Running", custom.WorkflowExecutionStatusCompleted, custom.WorkflowExecutionStatusFailed, custom.WorkflowExecutionStatusCanceled),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum number of executions to return. Defaults to %d", defaultExecutionsLimit),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 250),
+				},
+			},
+			"include_failure_history": schema.BoolAttribute{
+				Description: "Whether to read the step history of the latest failed execution into `latest_failure`",
+				Optional:    true,
+			},
+			"executions": schema.ListNestedAttribute{
+				Description: "The executions, newest first",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: executionAttributes,
+				},
+			},
+			"latest_failure": schema.SingleNestedAttribute{
+				Description: "The latest failed execution with its step history. Only read when `include_failure_history` is true, null when no execution failed",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"execution": schema.SingleNestedAttribute{
+						Description: "The failed execution",
+						Computed:    true,
+						Attributes:  executionAttributes,
+					},
+					"history": schema.ListNestedAttribute{
+						Description: "The events of the execution in the order they happened",
+						Computed:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"type": schema.StringAttribute{
+									Description: "The type of event, e.g. `ActivityTaskFailed`",
+									Computed:    true,
+								},
+								"timestamp": schema.StringAttribute{
+									Description: "The time of the event (RFC 3339)",
+									Computed:    true,
+								},
+								"step_name": schema.StringAttribute{
+									Description: "The step the event belongs to, null for events of the whole execution",
+									Computed:    true,
+								},
+								"attributes": schema.StringAttribute{
+									Description: "The attributes of the event as JSON, e.g. the error of a failed step",
+									Computed:    true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *workflowExecutionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config workflowExecutionsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	workflowId := config.WorkflowId.ValueString()
+
+	limit := defaultExecutionsLimit
+	if !config.Limit.IsNull() {
+		limit = int(config.Limit.ValueInt64())
+	}
+	filters := ""
+	if !config.Status.IsNull() {
+		filters = "status eq \"" + config.Status.ValueString() + "\""
+	}
+	executions, spResp, err := d.apiClient.ListWorkflowExecutions(ctx, workflowId, limit, filters)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Workflow Executions",
+			"Could not read executions of Workflow '"+workflowId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+	config.Executions = make([]workflowExecutionModel, 0, len(executions))
+	for i := range executions {
+		config.Executions = append(config.Executions, newWorkflowExecutionModel(&executions[i]))
+	}
+
+	config.LatestFailure = nil
+	if config.IncludeFailureHistory.ValueBool() {
+		failed, spResp, err := d.apiClient.ListWorkflowExecutions(ctx, workflowId, 1, "status eq \""+custom.WorkflowExecutionStatusFailed+"\"")
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Workflow Executions",
+				"Could not read failed executions of Workflow '"+workflowId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+			)
+			return
+		}
+		if len(failed) > 0 {
+			history, spResp, err := d.apiClient.GetWorkflowExecutionHistory(ctx, failed[0].Id)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Read Workflow Execution History",
+					"Could not read history of execution '"+failed[0].Id+"' of Workflow '"+workflowId+"': "+err.Error()+"\n"+util.GetBody(spResp),
+				)
+				return
+			}
+			config.LatestFailure = &workflowExecutionFailureModel{
+				Execution: newWorkflowExecutionModel(&failed[0]),
+				History:   make([]workflowExecutionEventModel, 0, len(history)),
+			}
+			for i := range history {
+				config.LatestFailure.History = append(config.LatestFailure.History, newWorkflowExecutionEventModel(&history[i]))
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+func newWorkflowExecutionModel(execution *custom.WorkflowExecution) workflowExecutionModel {
+	model := workflowExecutionModel{
+		Id:          types.StringValue(execution.Id),
+		RequestId:   types.StringValue(execution.RequestId),
+		TriggerType: types.StringNull(),
+		Status:      types.StringValue(execution.Status),
+		StartTime:   formatTime(execution.StartTime),
+		CloseTime:   formatTime(execution.CloseTime),
+	}
+	if execution.TriggerType != "" {
+		model.TriggerType = types.StringValue(execution.TriggerType)
+	}
+	return model
+}
+
+func newWorkflowExecutionEventModel(event *custom.WorkflowExecutionEvent) workflowExecutionEventModel {
+	model := workflowExecutionEventModel{
+		Type:       types.StringValue(event.Type),
+		Timestamp:  formatTime(event.Timestamp),
+		StepName:   types.StringNull(),
+		Attributes: types.StringNull(),
+	}
+	if step := event.StepName(); step != "" {
+		model.StepName = types.StringValue(step)
+	}
+	if event.Attributes != nil {
+		attributes, _ := json.Marshal(event.Attributes)
+		model.Attributes = types.StringValue(string(attributes))
+	}
+	return model
+}
+
+func formatTime(value *time.Time) types.String {
+	if value == nil || value.IsZero() {
+		return types.StringNull()
+	}
+	return types.StringValue(value.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,33 @@
+package workflow_execution
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type workflowExecutionsModel struct {
+	WorkflowId            types.String                   `tfsdk:"workflow_id"`
+	Status                types.String                   `tfsdk:"status"`
+	Limit                 types.Int64                    `tfsdk:"limit"`
+	IncludeFailureHistory types.Bool                     `tfsdk:"include_failure_history"`
+	Executions            []workflowExecutionModel       `tfsdk:"executions"`
+	LatestFailure         *workflowExecutionFailureModel `tfsdk:"latest_failure"`
+}
+
+type workflowExecutionModel struct {
+	Id          types.String `tfsdk:"id"`
+	RequestId   types.String `tfsdk:"request_id"`
+	TriggerType types.String `tfsdk:"trigger_type"`
+	Status      types.String `tfsdk:"status"`
+	StartTime   types.String `tfsdk:"start_time"`
+	CloseTime   types.String `tfsdk:"close_time"`
+}
+
+type workflowExecutionFailureModel struct {
+	Execution workflowExecutionModel        `tfsdk:"execution"`
+	History   []workflowExecutionEventModel `tfsdk:"history"`
+}
+
+type workflowExecutionEventModel struct {
+	Type       types.String `tfsdk:"type"`
+	Timestamp  types.String `tfsdk:"timestamp"`
+	StepName   types.String `tfsdk:"step_name"`
+	Attributes types.String `tfsdk:"attributes"`
+}
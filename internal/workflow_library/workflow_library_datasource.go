@@ -0,0 +1,188 @@
+package workflow_library
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-identitynow/internal/sailpoint/custom"
+	"terraform-provider-identitynow/internal/util"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Catalog of the Workflow Library - https://developer.sailpoint.com/docs/api/beta/workflow-library
+var (
+	_ datasource.DataSource              = &workflowLibraryDataSource{}
+	_ datasource.DataSourceWithConfigure = &workflowLibraryDataSource{}
+)
+
+func NewWorkflowActionsDataSource() datasource.DataSource {
+	return &workflowLibraryDataSource{
+		typeName:    "workflow_actions",
+		attribute:   "actions",
+		description: "Actions available for workflow steps, e.g. `sp:get-identities`",
+		list: func(ctx context.Context, client *custom.APIClient) ([]custom.WorkflowLibraryItem, *http.Response, error) {
+			return client.ListWorkflowLibraryActions(ctx)
+		},
+	}
+}
+
+func NewWorkflowTriggersDataSource() datasource.DataSource {
+	return &workflowLibraryDataSource{
+		typeName:    "workflow_triggers",
+		attribute:   "triggers",
+		description: "Triggers which can start a workflow, e.g. `idn:identity-created`",
+		list: func(ctx context.Context, client *custom.APIClient) ([]custom.WorkflowLibraryItem, *http.Response, error) {
+			return client.ListWorkflowLibraryTriggers(ctx)
+		},
+	}
+}
+
+func NewWorkflowOperatorsDataSource() datasource.DataSource {
+	return &workflowLibraryDataSource{
+		typeName:    "workflow_operators",
+		attribute:   "operators",
+		description: "Operators controlling the flow of workflow steps, e.g. `sp:loop:iterator`",
+		list: func(ctx context.Context, client *custom.APIClient) ([]custom.WorkflowLibraryItem, *http.Response, error) {
+			return client.ListWorkflowLibraryOperators(ctx)
+		},
+	}
+}
+
+// workflowLibraryDataSource lists one kind of items of the Workflow Library. All kinds share the same schema.
+type workflowLibraryDataSource struct {
+	apiClient   *custom.APIClient
+	typeName    string
+	attribute   string
+	description string
+	list        func(ctx context.Context, client *custom.APIClient) ([]custom.WorkflowLibraryItem, *http.Response, error)
+}
+
+func (d *workflowLibraryDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*custom.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *sailpoint.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.apiClient = client
+}
+
+func (d *workflowLibraryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + d.typeName
+}
+
+func (d *workflowLibraryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: d.description,
+		Attributes: map[string]schema.Attribute{
+			d.attribute: schema.ListNestedAttribute{
+				Description: d.description,
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The id used in workflow definitions, e.g. `actionId` of a step",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description",
+							Computed:    true,
+						},
+						"version_number": schema.Int64Attribute{
+							Description: "The latest version, used as `versionNumber` of a step",
+							Computed:    true,
+						},
+						"deprecated": schema.BoolAttribute{
+							Description: "Whether it is deprecated",
+							Computed:    true,
+						},
+						"form_fields": schema.ListNestedAttribute{
+							Description: "The inputs it accepts",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Description: "The name of the input attribute",
+										Computed:    true,
+									},
+									"label": schema.StringAttribute{
+										Description: "The label of the input in the UI",
+										Computed:    true,
+									},
+									"help_text": schema.StringAttribute{
+										Description: "The description of the input in the UI",
+										Computed:    true,
+									},
+									"required": schema.BoolAttribute{
+										Description: "Whether the input is required",
+										Computed:    true,
+									},
+									"type": schema.StringAttribute{
+										Description: "The type of the input",
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *workflowLibraryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	items, spResp, err := d.list(ctx, d.apiClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Workflow Library",
+			"Could not read Workflow Library "+d.attribute+": "+err.Error()+"\n"+util.GetBody(spResp),
+		)
+		return
+	}
+	models := make([]workflowLibraryItemModel, len(items))
+	for i, item := range items {
+		models[i] = mapToTerraformModel(&item)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(d.attribute), models)...)
+}
+
+func mapToTerraformModel(item *custom.WorkflowLibraryItem) workflowLibraryItemModel {
+	formFields := make([]workflowLibraryFormFieldModel, len(item.FormFields))
+	for i, formField := range item.FormFields {
+		formFields[i] = workflowLibraryFormFieldModel{
+			Name:     types.StringValue(formField.Name),
+			Label:    types.StringValue(formField.Label),
+			HelpText: types.StringValue(formField.HelpText),
+			Required: types.BoolValue(formField.Required),
+			Type:     types.StringValue(string(formField.Type)),
+		}
+	}
+	return workflowLibraryItemModel{
+		Id:            types.StringValue(item.Id),
+		Name:          types.StringValue(item.Name),
+		Type:          types.StringValue(string(item.Type)),
+		Description:   types.StringValue(item.Description),
+		VersionNumber: types.Int64PointerValue(item.VersionNumber),
+		Deprecated:    types.BoolValue(item.Deprecated),
+		FormFields:    formFields,
+	}
+}
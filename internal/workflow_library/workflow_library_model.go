@@ -0,0 +1,21 @@
+package workflow_library
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type workflowLibraryItemModel struct {
+	Id            types.String                    `tfsdk:"id"`
+	Name          types.String                    `tfsdk:"name"`
+	Type          types.String                    `tfsdk:"type"`
+	Description   types.String                    `tfsdk:"description"`
+	VersionNumber types.Int64                     `tfsdk:"version_number"`
+	Deprecated    types.Bool                      `tfsdk:"deprecated"`
+	FormFields    []workflowLibraryFormFieldModel `tfsdk:"form_fields"`
+}
+
+type workflowLibraryFormFieldModel struct {
+	Name     types.String `tfsdk:"name"`
+	Label    types.String `tfsdk:"label"`
+	HelpText types.String `tfsdk:"help_text"`
+	Required types.Bool   `tfsdk:"required"`
+	Type     types.String `tfsdk:"type"`
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
+	"terraform-provider-identitynow/internal/export"
 	"terraform-provider-identitynow/internal/provider"
 )
 
@@ -20,6 +22,14 @@ var (
 )
 
 func main() {
+	// `export` subcommand generates Terraform configuration from an existing tenant
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := export.Run(context.Background(), os.Args[2:]); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")